@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -13,8 +14,14 @@ import (
 	"github.com/argami/gormeparser/internal/download"
 	"github.com/argami/gormeparser/internal/models"
 	"github.com/argami/gormeparser/internal/parser"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
 )
 
+// anuncioFilters are the filters selectable with -filter
+var anuncioFilters = map[string]models.AnuncioFilter{
+	"concursal": models.IsConcursal,
+}
+
 func main() {
 	// File/directory mode flags
 	file := flag.String("file", "", "BORME file or directory to parse")
@@ -22,6 +29,11 @@ func main() {
 	output := flag.String("output", "", "Output directory for JSON files")
 	pretty := flag.Bool("pretty", false, "Pretty-print JSON output")
 	workers := flag.Int("workers", 4, "Number of parallel workers for batch processing")
+	strict := flag.Bool("strict", false, "Fail on parse warnings instead of reporting them")
+	extractor := flag.String("extractor", "pdf", "Text extractor for Section A: pdf (built-in decoder) or text (.txt file alongside each PDF)")
+	filter := flag.String("filter", "", "Output only some anuncios: concursal (insolvency actos, Section A)")
+	tipo := flag.String("tipo", "", "Output only the Section C announcements of these comma-separated types: "+tiposC())
+	provenance := flag.Bool("provenance", false, "Include the source page and text of each anuncio and acto (Section A)")
 
 	// Download + process mode flags
 	startDate := flag.String("start-date", "", "Start date (YYYY-MM-DD) for download+process")
@@ -31,6 +43,33 @@ func main() {
 
 	flag.Parse()
 
+	textExtractor, err := pypdf2.ExtractorByName(*extractor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts := parser.Options{Strict: *strict, Provenance: *provenance, Extractor: textExtractor}
+	if *filter != "" {
+		anuncioFilter, ok := anuncioFilters[*filter]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: unknown filter %q\n", *filter)
+			os.Exit(1)
+		}
+		opts.Filter = anuncioFilter
+	}
+	if *tipo != "" {
+		var tipos []models.TipoAnuncioC
+		for _, name := range strings.Split(*tipo, ",") {
+			t, ok := models.ParseTipoAnuncioC(name)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: unknown Section C type %q\n", name)
+				os.Exit(1)
+			}
+			tipos = append(tipos, t)
+		}
+		opts.FilterC = models.TipoCFilter(tipos...)
+	}
+
 	// Check which mode to use
 	hasDateRange := *startDate != "" && *endDate != ""
 	hasFileOrDir := *file != ""
@@ -43,7 +82,7 @@ func main() {
 
 	if hasDateRange {
 		// Download + process mode
-		downloadAndProcess(*startDate, *endDate, *provincia, *seccion, *downloadDir, *output, *pretty, *workers)
+		downloadAndProcess(*startDate, *endDate, *provincia, *seccion, *downloadDir, *output, *pretty, *workers, opts)
 		return
 	}
 
@@ -60,18 +99,34 @@ func main() {
 	}
 
 	if info.IsDir() {
-		batchProcess(*file, *seccion, *output, *pretty, *workers)
+		batchProcess(*file, *seccion, *output, *pretty, *workers, opts)
 	} else {
-		singleProcess(*file, *seccion, *output, *pretty)
+		singleProcess(*file, *seccion, *output, *pretty, opts)
+	}
+}
+
+// tiposC lists the Section C types accepted by -tipo
+func tiposC() string {
+	names := make([]string, len(models.TiposAnuncioC))
+	for i, t := range models.TiposAnuncioC {
+		names[i] = string(t)
 	}
+	return strings.Join(names, ", ")
 }
 
-func singleProcess(filename, seccion, output string, pretty bool) {
-	result, err := parser.Parse(filename, models.Seccion(seccion))
+func singleProcess(filename, seccion, output string, pretty bool, opts parser.Options) {
+	result, err := parser.ParseWith(filename, models.Seccion(seccion), opts)
+	if errors.Is(err, parser.ErrFiltered) {
+		return
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", filename, err)
 		os.Exit(1)
 	}
+	if err := reportDiagnostics(filename, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", filename, err)
+		os.Exit(1)
+	}
 
 	var data []byte
 	var jsonErr error
@@ -79,6 +134,8 @@ func singleProcess(filename, seccion, output string, pretty bool) {
 	switch b := result.(type) {
 	case *models.Borme:
 		data, jsonErr = models.BormeToJSON(b, pretty)
+	case *models.BormeB:
+		data, jsonErr = models.BormeBToJSON(b, pretty)
 	case *models.BormeC:
 		data, jsonErr = bormeCToJSON(b, pretty)
 	default:
@@ -104,7 +161,7 @@ func singleProcess(filename, seccion, output string, pretty bool) {
 	}
 }
 
-func batchProcess(dir, seccion, output string, pretty bool, workers int) {
+func batchProcess(dir, seccion, output string, pretty bool, workers int, opts parser.Options) {
 	var files []string
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -158,7 +215,7 @@ func batchProcess(dir, seccion, output string, pretty bool, workers int) {
 				outFile = filepath.Join(output, strings.TrimSuffix(baseName, filepath.Ext(baseName))+".json")
 			}
 
-			err := processFile(filename, models.Seccion(seccion), outFile, pretty)
+			err := processFile(filename, models.Seccion(seccion), outFile, pretty, opts)
 			results <- struct {
 				file string
 				err  error
@@ -182,7 +239,7 @@ func batchProcess(dir, seccion, output string, pretty bool, workers int) {
 	fmt.Printf("\nDone: %d successful, %d failed\n", success, failed)
 }
 
-func downloadAndProcess(startDate, endDate, provincia, seccion, downloadDir, output string, pretty bool, workers int) {
+func downloadAndProcess(startDate, endDate, provincia, seccion, downloadDir, output string, pretty bool, workers int, opts parser.Options) {
 	// Parse dates
 	start, err := time.Parse("2006-01-02", startDate)
 	if err != nil {
@@ -196,16 +253,30 @@ func downloadAndProcess(startDate, endDate, provincia, seccion, downloadDir, out
 		os.Exit(1)
 	}
 
-	// Normalize province
+	// Normalize province; Section A and B bulletins are published per
+	// province and carry its INE code in the CVE
+	sec := models.Seccion(strings.ToUpper(seccion))
 	provCode := provincia
+	provINE := 0
 	if provincia != "" {
+		if sec == models.SeccionC {
+			fmt.Fprintln(os.Stderr, "The province filter only applies to sections A and B")
+			os.Exit(1)
+		}
 		provCode = normalizeProvincia(provincia)
-		if provCode == "" {
+		provINE = provinciaINE(provCode)
+		if provINE == 0 {
 			fmt.Fprintf(os.Stderr, "Unknown province: %s\n", provincia)
 			os.Exit(1)
 		}
 	}
 
+	// Section C anuncios are downloaded as XML documents
+	format := "pdf"
+	if sec == models.SeccionC {
+		format = "xml"
+	}
+
 	// Create directories
 	if err := os.MkdirAll(downloadDir, 0755); err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating download directory: %v\n", err)
@@ -225,74 +296,105 @@ func downloadAndProcess(startDate, endDate, provincia, seccion, downloadDir, out
 		dates = append(dates, d)
 	}
 
-	fmt.Printf("Downloading and processing BORME %s from %s to %s\n", seccion, startDate, endDate)
+	fmt.Printf("Downloading and processing BORME %s from %s to %s\n", sec, startDate, endDate)
 	if provincia != "" {
 		fmt.Printf("Province filter: %s (%s)\n", provincia, provCode)
 	}
-	fmt.Printf("Processing %d dates with %d workers...\n", len(dates), workers)
+
+	type job struct {
+		date time.Time
+		cve  models.CVE
+	}
+	type result struct {
+		name string
+		err  error
+	}
+
+	// The daily sumario lists the documents published, so no bulletin
+	// number or province has to be guessed
+	var jobs []job
+	var failed int
+	for _, d := range dates {
+		cves, err := sumarioCVEs(d, sec, downloadDir)
+		if err != nil {
+			fmt.Printf("FAIL: %s - %v\n", d.Format("2006-01-02"), err)
+			failed++
+			continue
+		}
+		for _, cve := range cves {
+			if provINE != 0 && cve.Provincia != provINE {
+				continue
+			}
+			jobs = append(jobs, job{d, cve})
+		}
+	}
+
+	fmt.Printf("Processing %d documents from %d dates with %d workers...\n", len(jobs), len(dates), workers)
 
 	// Download and process in parallel
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, workers)
-	results := make(chan struct {
-		date   time.Time
-		file   string
-		err    error
-	}, len(dates))
+	results := make(chan result, len(jobs))
 
-	for _, date := range dates {
+	for _, j := range jobs {
 		wg.Add(1)
 		sem <- struct{}{}
 
-		go func(d time.Time) {
+		go func(j job) {
 			defer wg.Done()
 			defer func() { <-sem }()
 
-			// Get URL
-			url := download.GetURLPDF(d, seccion, provCode)
-
-			// Generate filename
-			filename := filepath.Join(downloadDir, fmt.Sprintf("BORME-%s-%s.pdf", seccion, d.Format("2006-01-02")))
-
-			// Download
-			if err := download.DownloadFile(url, filename); err != nil {
-				results <- struct {
-					date   time.Time
-					file   string
-					err    error
-				}{d, "", err}
+			// Download, named after the CVE
+			filename, err := download.DownloadCVE(j.cve, j.date, format, downloadDir)
+			if err != nil {
+				results <- result{j.cve.String(), err}
 				return
 			}
 
 			// Parse
 			var outFile string
 			if output != "" {
-				outFile = filepath.Join(output, fmt.Sprintf("BORME-%s-%s.json", seccion, d.Format("2006-01-02")))
+				outFile = filepath.Join(output, j.cve.Filename(".json"))
 			}
 
-			err := processFile(filename, models.Seccion(seccion), outFile, pretty)
-			results <- struct {
-				date   time.Time
-				file   string
-				err    error
-			}{d, filename, err}
-		}(date)
+			err = processFile(filename, sec, outFile, pretty, opts)
+			results <- result{j.cve.String(), err}
+		}(j)
 	}
 
 	wg.Wait()
 	close(results)
 
-	var success, failed int
+	var success int
 	for r := range results {
 		if r.err != nil {
-			fmt.Printf("FAIL: %s - %v\n", r.date.Format("2006-01-02"), r.err)
+			fmt.Printf("FAIL: %s - %v\n", r.name, r.err)
 			failed++
 		} else {
 			success++
 		}
 	}
 
-	fmt.Printf("\nDone: %d dates processed, %d failed\n", success, failed)
+	fmt.Printf("\nDone: %d documents processed, %d failed\n", success, failed)
+}
+
+// sumarioCVEs downloads the sumario of a date into dir and returns the CVEs
+// it lists for the section
+func sumarioCVEs(date time.Time, seccion models.Seccion, dir string) ([]models.CVE, error) {
+	filename := filepath.Join(dir, fmt.Sprintf("BORME-S-%s.xml", date.Format("20060102")))
+	if err := download.DownloadXML(date, filename); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	index, err := download.ParseSumario(data)
+	if err != nil {
+		return nil, err
+	}
+	return index.CVEs(seccion)
 }
 
 func normalizeProvincia(prov string) string {
@@ -315,32 +417,32 @@ func normalizeProvincia(prov string) string {
 
 	// Try as name
 	nameMap := map[string]string{
-		"madrid":              "Madrid",
-		"barcelona":           "Barcelona",
-		"valencia":            "Valencia",
-		"sevilla":             "Sevilla",
-		"murcia":              "Murcia",
-		"asturias":            "Asturias",
-		"balears":             "Illes Balears",
-		"islas balears":       "Illes Balears",
-		"las Palmas":          "Las Palmas",
-		"gran canaria":        "Las Palmas",
-		"tenerife":           "Santa Cruz de Tenerife",
-		"canarias":           "Santa Cruz de Tenerife",
-		"cadiz":               "Cadiz",
-		"malaga":              "Malaga",
-		"bizkaia":             "Bizkaia",
-		"biscay":              "Bizkaia",
-		"vizcaya":             "Bizkaia",
-		"gipuzkoa":            "Gipuzkoa",
-		"navarra":             "Navarra",
-		"araba":               "Araba/Álava",
-		"alava":               "Araba/Álava",
-		"alicante":            "Alicante",
-		"coruña":              "La Coruña",
-		"a coruña":            "La Coruña",
-		"pontevedra":          "Pontevedra",
-		"galicia":             "La Coruña",
+		"madrid":        "Madrid",
+		"barcelona":     "Barcelona",
+		"valencia":      "Valencia",
+		"sevilla":       "Sevilla",
+		"murcia":        "Murcia",
+		"asturias":      "Asturias",
+		"balears":       "Illes Balears",
+		"islas balears": "Illes Balears",
+		"las Palmas":    "Las Palmas",
+		"gran canaria":  "Las Palmas",
+		"tenerife":      "Santa Cruz de Tenerife",
+		"canarias":      "Santa Cruz de Tenerife",
+		"cadiz":         "Cadiz",
+		"malaga":        "Malaga",
+		"bizkaia":       "Bizkaia",
+		"biscay":        "Bizkaia",
+		"vizcaya":       "Bizkaia",
+		"gipuzkoa":      "Gipuzkoa",
+		"navarra":       "Navarra",
+		"araba":         "Araba/Álava",
+		"alava":         "Araba/Álava",
+		"alicante":      "Alicante",
+		"coruña":        "A Coruña",
+		"a coruña":      "A Coruña",
+		"pontevedra":    "Pontevedra",
+		"galicia":       "A Coruña",
 	}
 
 	provLower := strings.ToLower(prov)
@@ -353,11 +455,25 @@ func normalizeProvincia(prov string) string {
 	return ""
 }
 
-func processFile(filename string, seccion models.Seccion, outputFile string, pretty bool) error {
-	result, err := parser.Parse(filename, seccion)
+// provinciaINE returns the INE code of a province name, or 0 if unknown
+func provinciaINE(name string) int {
+	if p := models.ProvinciaFromName(name); p != nil {
+		return p.INE
+	}
+	return 0
+}
+
+func processFile(filename string, seccion models.Seccion, outputFile string, pretty bool, opts parser.Options) error {
+	result, err := parser.ParseWith(filename, seccion, opts)
+	if errors.Is(err, parser.ErrFiltered) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
+	if err := reportDiagnostics(filename, result); err != nil {
+		return err
+	}
 
 	var data []byte
 	var jsonErr error
@@ -365,6 +481,8 @@ func processFile(filename string, seccion models.Seccion, outputFile string, pre
 	switch b := result.(type) {
 	case *models.Borme:
 		data, jsonErr = models.BormeToJSON(b, pretty)
+	case *models.BormeB:
+		data, jsonErr = models.BormeBToJSON(b, pretty)
 	case *models.BormeC:
 		data, jsonErr = bormeCToJSON(b, pretty)
 	default:
@@ -382,6 +500,29 @@ func processFile(filename string, seccion models.Seccion, outputFile string, pre
 	return nil
 }
 
+// reportDiagnostics prints the parse warnings of a result. A result with
+// errors (e.g. the text of the PDF could not be extracted) is not a valid
+// bulletin: its errors are returned instead, so that the file counts as failed.
+func reportDiagnostics(filename string, result interface{}) error {
+	var diags models.Diagnostics
+	switch b := result.(type) {
+	case *models.Borme:
+		diags = b.Diagnostics
+	case *models.BormeB:
+		diags = b.Diagnostics
+	case *models.BormeC:
+		diags = b.Diagnostics
+	}
+
+	if diags.HasErrors() {
+		return &models.DiagnosticsError{Filename: filename, Diagnostics: diags.Filter(models.SeverityError)}
+	}
+	for _, d := range diags.Filter(models.SeverityWarning) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", filename, d)
+	}
+	return nil
+}
+
 func bormeCToJSON(b *models.BormeC, pretty bool) ([]byte, error) {
 	if pretty {
 		data, err := json.MarshalIndent(b, "", "  ")
@@ -0,0 +1,109 @@
+package cnae
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+)
+
+// MaxClasificaciones is the number of classifications returned by Classify
+const MaxClasificaciones = 3
+
+// Classify assigns CNAE-2009 codes to an objeto social using the Reglas
+// table, most likely first. The confidence of a code is its share of the
+// points of all matching codes, halved when it rests on a single word.
+func Classify(objeto string) []models.ClasificacionCNAE {
+	texto := " " + palabras(objeto) + " "
+
+	type candidato struct {
+		regla  *Regla
+		puntos int
+	}
+	var candidatos []candidato
+	total := 0
+	for i := range Reglas {
+		puntos := 0
+		for _, kw := range Reglas[i].Keywords {
+			if strings.Contains(texto, " "+kw+" ") {
+				puntos += len(strings.Fields(kw))
+			}
+		}
+		if puntos > 0 {
+			candidatos = append(candidatos, candidato{&Reglas[i], puntos})
+			total += puntos
+		}
+	}
+	sort.SliceStable(candidatos, func(i, j int) bool { return candidatos[i].puntos > candidatos[j].puntos })
+
+	var result []models.ClasificacionCNAE
+	for _, c := range candidatos[:min(len(candidatos), MaxClasificaciones)] {
+		confianza := float64(c.puntos) / float64(total) * math.Min(1, float64(c.puntos)/2)
+		result = append(result, models.ClasificacionCNAE{
+			Codigo:      c.regla.Codigo,
+			Descripcion: c.regla.Descripcion,
+			Seccion:     Seccion(c.regla.Codigo),
+			Confianza:   math.Round(confianza*100) / 100,
+		})
+	}
+	return result
+}
+
+// Seccion returns the CNAE-2009 section letter of a division or class
+// code, e.g. "6832" -> "L", or "" if the code is unknown
+func Seccion(codigo string) string {
+	if len(codigo) < 2 {
+		return ""
+	}
+	division, err := strconv.Atoi(codigo[:2])
+	if err != nil {
+		return ""
+	}
+	for _, s := range secciones {
+		if division >= s.desde && division <= s.hasta {
+			return s.seccion
+		}
+	}
+	return ""
+}
+
+// NewObjetoSocial returns the objeto social with its classification
+func NewObjetoSocial(texto string) *models.ObjetoSocial {
+	return &models.ObjetoSocial{Texto: texto, CNAE: Classify(texto)}
+}
+
+// ConstitucionesPorSeccion counts the companies incorporated in the
+// bulletins by the CNAE section of their most likely activity; "" counts
+// the unclassified ones
+func ConstitucionesPorSeccion(bormes ...*models.Borme) map[string]int {
+	counts := make(map[string]int)
+	for _, b := range bormes {
+		for _, anuncio := range b.Anuncios {
+			for _, acto := range anuncio.Actos {
+				constitucion, ok := acto.(*models.BormeActoConstitucion)
+				if !ok {
+					continue
+				}
+				seccion := ""
+				if c := constitucion.ObjetoSocial.Principal(); c != nil {
+					seccion = c.Seccion
+				}
+				counts[seccion]++
+			}
+		}
+	}
+	return counts
+}
+
+// palabras lower-cases the text, folds its accents and keeps only words
+// separated by single spaces
+func palabras(s string) string {
+	s = strings.ToLower(normalize.FoldAccents(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+'
+	}), " ")
+}
@@ -0,0 +1,74 @@
+package cnae
+
+// Regla assigns a CNAE-2009 code to the objetos sociales mentioning any of
+// its keywords. Keywords are lower-case and folded like normalize.FoldAccents;
+// phrases weigh as many points as words they have.
+type Regla struct {
+	Codigo      string
+	Descripcion string
+	Keywords    []string
+}
+
+// Reglas is the bundled classification table
+var Reglas = []Regla{
+	{"01", "Agricultura, ganadería, caza y servicios relacionados", []string{"agricola", "agricolas", "agricultura", "ganaderia", "ganadera", "ganado", "cultivo", "cultivos", "explotacion agraria"}},
+	{"02", "Silvicultura y explotación forestal", []string{"forestal", "forestales", "silvicultura", "madera en rollo"}},
+	{"03", "Pesca y acuicultura", []string{"pesca", "pesquera", "acuicultura"}},
+	{"10", "Industria de la alimentación", []string{"alimentacion", "alimenticios", "productos alimenticios", "panaderia", "pasteleria", "conservas", "productos carnicos", "elaboracion de alimentos"}},
+	{"11", "Fabricación de bebidas", []string{"bebidas", "vino", "vinos", "bodega", "cerveza", "elaboracion de vinos"}},
+	{"13", "Industria textil", []string{"textil", "textiles", "tejidos"}},
+	{"14", "Confección de prendas de vestir", []string{"confeccion", "prendas de vestir", "moda"}},
+	{"25", "Fabricación de productos metálicos", []string{"productos metalicos", "carpinteria metalica", "estructuras metalicas", "caldereria"}},
+	{"31", "Fabricación de muebles", []string{"muebles", "mobiliario"}},
+	{"35", "Suministro de energía eléctrica, gas, vapor y aire acondicionado", []string{"energia", "energia electrica", "energia solar", "fotovoltaica", "fotovoltaicas", "renovables", "energias renovables"}},
+	{"41", "Construcción de edificios", []string{"construccion", "construcciones", "edificios", "promocion inmobiliaria", "promocion de edificaciones", "edificacion"}},
+	{"42", "Ingeniería civil", []string{"obra civil", "obras publicas", "carreteras", "urbanizacion"}},
+	{"43", "Actividades de construcción especializada", []string{"fontaneria", "instalaciones electricas", "reformas", "albañileria", "pintura", "climatizacion", "demolicion"}},
+	{"45", "Venta y reparación de vehículos de motor", []string{"vehiculos", "automoviles", "taller mecanico", "reparacion de vehiculos", "motocicletas"}},
+	{"46", "Comercio al por mayor", []string{"al por mayor", "mayorista", "distribucion", "importacion", "exportacion"}},
+	{"47", "Comercio al por menor", []string{"al por menor", "minorista", "tienda", "tiendas", "venta al publico", "comercio"}},
+	{"49", "Transporte terrestre", []string{"transporte", "transportes", "transporte de mercancias", "transporte por carretera", "transporte de viajeros", "mudanzas"}},
+	{"52", "Almacenamiento y actividades anexas al transporte", []string{"almacenamiento", "logistica", "logisticos", "deposito de mercancias"}},
+	{"55", "Servicios de alojamiento", []string{"hotel", "hoteles", "hotelera", "alojamiento", "alojamientos", "hostal", "apartamentos turisticos"}},
+	{"56", "Servicios de comidas y bebidas", []string{"restaurante", "restaurantes", "restauracion", "bar", "bares", "cafeteria", "catering", "hosteleria"}},
+	{"58", "Edición", []string{"edicion", "editorial", "publicaciones"}},
+	{"59", "Actividades cinematográficas, de vídeo y de programas de televisión", []string{"audiovisual", "audiovisuales", "cinematografica", "cinematograficas", "produccion de video"}},
+	{"61", "Telecomunicaciones", []string{"telecomunicaciones", "telefonia"}},
+	{"62", "Programación, consultoría y otras actividades relacionadas con la informática", []string{"informatica", "informaticos", "software", "programacion", "aplicaciones informaticas", "desarrollo de aplicaciones", "tecnologias de la informacion"}},
+	{"63", "Servicios de información", []string{"internet", "portales web", "proceso de datos", "paginas web"}},
+	{"64", "Servicios financieros, excepto seguros y fondos de pensiones", []string{"financiera", "financieras", "holding", "tenencia de acciones", "tenencia de participaciones", "participaciones sociales", "gestion de patrimonio"}},
+	{"65", "Seguros, reaseguros y fondos de pensiones", []string{"seguros", "reaseguros"}},
+	{"66", "Actividades auxiliares a los servicios financieros y a los seguros", []string{"correduria", "correduria de seguros", "intermediacion financiera", "agencia de seguros"}},
+	{"68", "Actividades inmobiliarias", []string{"inmobiliaria", "inmobiliarias", "inmuebles", "bienes inmuebles", "arrendamiento de inmuebles", "compraventa de inmuebles", "alquiler de viviendas", "fincas"}},
+	{"6832", "Gestión y administración de la propiedad inmobiliaria", []string{"administracion de fincas", "gestion de fincas", "administracion de comunidades"}},
+	{"69", "Actividades jurídicas y de contabilidad", []string{"asesoria", "contabilidad", "juridica", "juridicos", "fiscal", "abogados", "gestoria", "auditoria"}},
+	{"70", "Actividades de las sedes centrales; consultoría de gestión empresarial", []string{"consultoria", "gestion empresarial", "asesoramiento empresarial"}},
+	{"71", "Servicios técnicos de arquitectura e ingeniería", []string{"arquitectura", "ingenieria", "proyectos tecnicos", "ensayos y analisis tecnicos"}},
+	{"72", "Investigación y desarrollo", []string{"investigacion", "investigacion y desarrollo", "biotecnologia"}},
+	{"73", "Publicidad y estudios de mercado", []string{"publicidad", "marketing", "estudios de mercado"}},
+	{"74", "Otras actividades profesionales, científicas y técnicas", []string{"diseño", "fotografia", "traduccion", "interpretacion"}},
+	{"75", "Actividades veterinarias", []string{"veterinaria", "veterinarias", "veterinario"}},
+	{"77", "Actividades de alquiler", []string{"alquiler de vehiculos", "alquiler de maquinaria", "alquiler de equipos"}},
+	{"78", "Actividades relacionadas con el empleo", []string{"trabajo temporal", "seleccion de personal", "empleo"}},
+	{"79", "Agencias de viajes y operadores turísticos", []string{"agencia de viajes", "viajes", "operador turistico"}},
+	{"81", "Servicios a edificios y actividades de jardinería", []string{"limpieza", "jardineria", "mantenimiento de edificios"}},
+	{"82", "Actividades administrativas de oficina y otras actividades auxiliares a las empresas", []string{"call center", "centro de llamadas", "servicios administrativos"}},
+	{"85", "Educación", []string{"educacion", "formacion", "enseñanza", "academia", "escuela", "cursos"}},
+	{"86", "Actividades sanitarias", []string{"sanitaria", "sanitarias", "medica", "medicos", "clinica", "odontologia", "fisioterapia"}},
+	{"87", "Asistencia en establecimientos residenciales", []string{"residencia", "residencias", "geriatrica", "tercera edad"}},
+	{"90", "Actividades de creación, artísticas y espectáculos", []string{"artisticas", "espectaculos", "teatro", "musica"}},
+	{"93", "Actividades deportivas, recreativas y de entretenimiento", []string{"deportivas", "deporte", "gimnasio", "ocio"}},
+	{"96", "Otros servicios personales", []string{"peluqueria", "estetica", "belleza", "lavanderia"}},
+}
+
+// secciones maps the CNAE-2009 divisions to their section letter
+var secciones = []struct {
+	desde, hasta int
+	seccion      string
+}{
+	{1, 3, "A"}, {5, 9, "B"}, {10, 33, "C"}, {35, 35, "D"}, {36, 39, "E"},
+	{41, 43, "F"}, {45, 47, "G"}, {49, 53, "H"}, {55, 56, "I"}, {58, 63, "J"},
+	{64, 66, "K"}, {68, 68, "L"}, {69, 75, "M"}, {77, 82, "N"}, {84, 84, "O"},
+	{85, 85, "P"}, {86, 88, "Q"}, {90, 93, "R"}, {94, 96, "S"}, {97, 98, "T"},
+	{99, 99, "U"},
+}
@@ -13,6 +13,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/argami/gormeparser/internal/models"
 )
 
 // Constants from Python's download.py
@@ -169,8 +171,8 @@ func GetURLPDF(date time.Time, seccion string, provincia string) string {
 // GetURLXML returns the URL for the daily XML index
 func GetURLXML(date time.Time) string {
 	urlStr := strings.ReplaceAll(BormeXMLURL, "{year}", strconv.Itoa(date.Year()))
-	urlStr = strings.ReplaceAll(urlStr, "{month}", strconv.Itoa(int(date.Month())))
-	urlStr = strings.ReplaceAll(urlStr, "{day}", strconv.Itoa(date.Day()))
+	urlStr = strings.ReplaceAll(urlStr, "{month:02d}", fmt.Sprintf("%02d", int(date.Month())))
+	urlStr = strings.ReplaceAll(urlStr, "{day:02d}", fmt.Sprintf("%02d", date.Day()))
 
 	return urlStr
 }
@@ -208,43 +210,118 @@ func DownloadPDF(date time.Time, filename string, seccion string, provincia stri
 	return DownloadFile(urlStr, filename)
 }
 
-// BormeXMLIndex represents the XML index structure
+// GetURLCVE returns the URL of a document by its CVE in the given format
+// ("pdf", "xml" or "htm"). PDF URLs also need the publication date.
+func GetURLCVE(cve models.CVE, date time.Time, format string) (string, error) {
+	if err := cve.Validate(); err != nil {
+		return "", err
+	}
+
+	switch format {
+	case "pdf":
+		if date.IsZero() {
+			return "", fmt.Errorf("publication date required for PDF of %s", cve)
+		}
+		return cve.URLPDF(date), nil
+	case "xml":
+		return cve.URLXML(), nil
+	case "htm":
+		return cve.URLHTML(), nil
+	}
+	return "", fmt.Errorf("unknown format: %s", format)
+}
+
+// DownloadCVE downloads a document by its CVE into dir, named after the
+// CVE (e.g. "BORME-A-2015-101-28.pdf"), and returns the downloaded path
+func DownloadCVE(cve models.CVE, date time.Time, format, dir string) (string, error) {
+	urlStr, err := GetURLCVE(cve, date, format)
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, cve.Filename("."+format))
+	if err := DownloadFile(urlStr, filename); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// BormeXMLIndex represents the daily sumario (BORME-S-YYYYMMDD), which
+// lists the documents published in each section:
+//
+//	<sumario>
+//	  <meta><fecha>28/05/2015</fecha></meta>
+//	  <diario nbo="101">
+//	    <seccion num="A">
+//	      <emisor>
+//	        <item id="BORME-A-2015-101-28">
+//	          <titulo>MADRID</titulo>
+//	          <urlPdf>/borme/dias/2015/05/28/pdfs/BORME-A-2015-101-28.pdf</urlPdf>
+//	        </item>
 type BormeXMLIndex struct {
-	XMLName   xml.Name       `xml:"diario"`
-	Date      string         `xml:"date"`
-	NBO       int            `xml:"nbo"`
-	Secciones []SeccionData  `xml:"seccion"`
+	XMLName xml.Name   `xml:"sumario"`
+	Date    string     `xml:"meta>fecha"`
+	Diario  DiarioData `xml:"diario"`
+}
+
+// DiarioData represents the bulletin of the day in the XML index
+type DiarioData struct {
+	NBO       int           `xml:"nbo,attr"`
+	Secciones []SeccionData `xml:"seccion"`
 }
 
 // SeccionData represents a section in the XML index
 type SeccionData struct {
-	Letra   string     `xml:"letra,attr"`
-	Empresa []EmpresaData `xml:"empresa"`
+	Letra string     `xml:"num,attr"`
+	Items []ItemData `xml:"emisor>item"`
 }
 
-// EmpresaData represents a company in the XML index
-type EmpresaData struct {
-	IDCia      string     `xml:"id"`
-	Provincia  string     `xml:"provincia"`
-	NumBorme   string     `xml:"nbo"`
-	NumAnuncio string     `xml:"num"`
-	NumPagina  string     `xml:"pag"`
-	URLPDF     string     `xml:"urlpdf"`
-	URLCVE     string     `xml:"urlcve"`
+// ItemData represents a document in the XML index
+type ItemData struct {
+	CVE    string `xml:"id,attr"`
+	Titulo string `xml:"titulo"` // province in Sections A and B
+	URLPDF string `xml:"urlPdf"` // relative to URLBase
 }
 
-// ParseXMLIndex parses the XML index and returns CVE URLs
-func ParseXMLIndex(data []byte) ([]string, error) {
+// ParseSumario parses the daily XML index
+func ParseSumario(data []byte) (*BormeXMLIndex, error) {
 	var index BormeXMLIndex
 	if err := xml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid sumario: %w", err)
+	}
+	return &index, nil
+}
+
+// CVEs returns the CVEs of the documents listed in a section, in order
+func (i *BormeXMLIndex) CVEs(seccion models.Seccion) ([]models.CVE, error) {
+	var cves []models.CVE
+	for _, s := range i.Diario.Secciones {
+		if models.Seccion(s.Letra) != seccion {
+			continue
+		}
+		for _, item := range s.Items {
+			cve, err := models.ParseCVE(item.CVE)
+			if err != nil {
+				return nil, err
+			}
+			cves = append(cves, cve)
+		}
+	}
+	return cves, nil
+}
+
+// ParseXMLIndex parses the XML index and returns the PDF URLs it lists
+func ParseXMLIndex(data []byte) ([]string, error) {
+	index, err := ParseSumario(data)
+	if err != nil {
 		return nil, err
 	}
 
 	var urls []string
-	for _, seccion := range index.Secciones {
-		for _, empresa := range seccion.Empresa {
-			if empresa.URLCVE != "" {
-				urls = append(urls, empresa.URLCVE)
+	for _, seccion := range index.Diario.Secciones {
+		for _, item := range seccion.Items {
+			if item.URLPDF != "" {
+				urls = append(urls, URLBase+item.URLPDF)
 			}
 		}
 	}
@@ -287,11 +364,11 @@ func DownloadURLs(urls []string, path string, names []string) map[string]string
 
 // GetNBOFromXML extracts the bulletin number from XML
 func GetNBOFromXML(data []byte) (int, error) {
-	var index BormeXMLIndex
-	if err := xml.Unmarshal(data, &index); err != nil {
+	index, err := ParseSumario(data)
+	if err != nil {
 		return 0, err
 	}
-	return index.NBO, nil
+	return index.Diario.NBO, nil
 }
 
 // ValidateURL checks if a URL is valid
@@ -3,8 +3,11 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/argami/gormeparser/internal/normalize"
 )
 
 // Seccion represents the BORME section (A, B, or C)
@@ -20,60 +23,95 @@ const (
 type Provincia struct {
 	Code int    `json:"code"`
 	Name string `json:"name"`
+	INE  int    `json:"ine,omitempty"` // INE code, used in the CVE (28 in "BORME-A-2015-101-28")
 }
 
 var Provincias = map[string]Provincia{
-	"ALAVA":              {1, "Alava"},
-	"ALBACETE":           {2, "Albacete"},
-	"ALICANTE":           {3, "Alicante"},
-	"ALMERIA":            {4, "Almeria"},
-	"ARABA":              {1, "Araba/Álava"},
-	"ASTURIAS":           {330, "Asturias"},
-	"AVILA":              {50, "Avila"},
-	"BADAJOZ":            {60, "Badajoz"},
-	"BARCELONA":          {80, "Barcelona"},
-	"BISCAY":             {48, "Bizkaia"},
-	"BURGOS":             {90, "Burgos"},
-	"CACERES":            {100, "Caceres"},
-	"CADIZ":              {110, "Cadiz"},
-	"CANTABRIA":          {390, "Cantabria"},
-	"CASTELLON":          {120, "Castellon"},
-	"CEUTA":             {510, "Ceuta"},
-	"CORDOBA":           {140, "Cordoba"},
-	"CUENCA":            {160, "Cuenca"},
-	"GIPUZCOA":          {200, "Gipuzkoa"},
-	"GIRONA":            {170, "Girona"},
-	"GRANADA":           {180, "Granada"},
-	"GUADALAJARA":       {190, "Guadalajara"},
-	"HUELVA":            {210, "Huelva"},
-	"HUESCA":            {220, "Huesca"},
-	"ILLES BALEARS":     {70, "Illes Balears"},
-	"JAEN":              {230, "Jaen"},
-	"LA CORUÑA":         {150, "La Coruña"},
-	"LA RIOJA":          {260, "La Rioja"},
-	"LAS PALMAS":        {350, "Las Palmas"},
-	"LEON":              {240, "Leon"},
-	"LLEIDA":            {250, "Lleida"},
-	"LUGO":              {270, "Lugo"},
-	"MADRID":            {280, "Madrid"},
-	"MALAGA":            {290, "Malaga"},
-	"MELILLA":           {520, "Melilla"},
-	"MURCIA":            {300, "Murcia"},
-	"NAVARRA":           {310, "Navarra"},
-	"OURENSE":           {320, "Ourense"},
-	"PALENCIA":          {340, "Palencia"},
-	"PONTEVEDRA":        {360, "Pontevedra"},
-	"SALAMANCA":         {370, "Salamanca"},
-	"SEGOVIA":           {400, "Segovia"},
-	"SEVILLA":           {410, "Sevilla"},
-	"SORIA":             {420, "Soria"},
-	"TARRAGONA":         {430, "Tarragona"},
-	"TERUEL":            {440, "Teruel"},
-	"TOLEDO":            {450, "Toledo"},
-	"VALENCIA":          {460, "Valencia"},
-	"VALLADOLID":        {470, "Valladolid"},
-	"ZAMORA":            {490, "Zamora"},
-	"ZARAGOZA":          {500, "Zaragoza"},
+	"A CORUÑA":               {150, "A Coruña", 15},
+	"ALAVA":                  {1, "Alava", 1},
+	"ALBACETE":               {2, "Albacete", 2},
+	"ALICANTE":               {3, "Alicante", 3},
+	"ALMERIA":                {4, "Almeria", 4},
+	"ARABA":                  {1, "Araba/Álava", 1},
+	"ASTURIAS":               {330, "Asturias", 33},
+	"AVILA":                  {50, "Avila", 5},
+	"BADAJOZ":                {60, "Badajoz", 6},
+	"BARCELONA":              {80, "Barcelona", 8},
+	"BISCAY":                 {48, "Bizkaia", 48},
+	"BURGOS":                 {90, "Burgos", 9},
+	"CACERES":                {100, "Caceres", 10},
+	"CADIZ":                  {110, "Cadiz", 11},
+	"CANTABRIA":              {390, "Cantabria", 39},
+	"CASTELLON":              {120, "Castellon", 12},
+	"CEUTA":                  {510, "Ceuta", 51},
+	"CIUDAD REAL":            {130, "Ciudad Real", 13},
+	"CORDOBA":                {140, "Cordoba", 14},
+	"CUENCA":                 {160, "Cuenca", 16},
+	"GIPUZCOA":               {200, "Gipuzkoa", 20},
+	"GIRONA":                 {170, "Girona", 17},
+	"GRANADA":                {180, "Granada", 18},
+	"GUADALAJARA":            {190, "Guadalajara", 19},
+	"HUELVA":                 {210, "Huelva", 21},
+	"HUESCA":                 {220, "Huesca", 22},
+	"ILLES BALEARS":          {70, "Illes Balears", 7},
+	"JAEN":                   {230, "Jaen", 23},
+	"LA CORUÑA":              {150, "La Coruña", 15},
+	"LA RIOJA":               {260, "La Rioja", 26},
+	"LAS PALMAS":             {350, "Las Palmas", 35},
+	"LEON":                   {240, "Leon", 24},
+	"LLEIDA":                 {250, "Lleida", 25},
+	"LUGO":                   {270, "Lugo", 27},
+	"MADRID":                 {280, "Madrid", 28},
+	"MALAGA":                 {290, "Malaga", 29},
+	"MELILLA":                {520, "Melilla", 52},
+	"MURCIA":                 {300, "Murcia", 30},
+	"NAVARRA":                {310, "Navarra", 31},
+	"OURENSE":                {320, "Ourense", 32},
+	"PALENCIA":               {340, "Palencia", 34},
+	"PONTEVEDRA":             {360, "Pontevedra", 36},
+	"SALAMANCA":              {370, "Salamanca", 37},
+	"SANTA CRUZ DE TENERIFE": {380, "Santa Cruz de Tenerife", 38},
+	"SEGOVIA":                {400, "Segovia", 40},
+	"SEVILLA":                {410, "Sevilla", 41},
+	"SORIA":                  {420, "Soria", 42},
+	"TARRAGONA":              {430, "Tarragona", 43},
+	"TERUEL":                 {440, "Teruel", 44},
+	"TOLEDO":                 {450, "Toledo", 45},
+	"VALENCIA":               {460, "Valencia", 46},
+	"VALLADOLID":             {470, "Valladolid", 47},
+	"ZAMORA":                 {490, "Zamora", 49},
+	"ZARAGOZA":               {500, "Zaragoza", 50},
+}
+
+// ProvinciaFromINE returns the Provincia for an INE province code, or nil
+// if unknown. Aliases sharing a code resolve to the first key in
+// alphabetical order.
+func ProvinciaFromINE(code int) *Provincia {
+	keys := make([]string, 0, len(Provincias))
+	for key, p := range Provincias {
+		if p.INE == code {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	p := Provincias[keys[0]]
+	return &p
+}
+
+// ProvinciaFromName returns the Provincia whose key or name matches a
+// province name as published (e.g. "MÁLAGA", "Araba/Álava"), ignoring
+// case and accents, or nil if unknown
+func ProvinciaFromName(name string) *Provincia {
+	name = strings.ToUpper(normalize.FoldAccents(strings.TrimSpace(name)))
+	for key, p := range Provincias {
+		if key == name || strings.ToUpper(normalize.FoldAccents(p.Name)) == name {
+			return &p
+		}
+	}
+	return nil
 }
 
 // FromTitle returns the Provincia matching a title (case-insensitive partial match)
@@ -91,15 +129,18 @@ func FromTitle(title string) *Provincia {
 type BormeActo interface {
 	GetName() string
 	GetValue() interface{}
+	GetProvenance() *Provenance
+	SetProvenance(p *Provenance)
 }
 
 // BormeActoTexto represents a text-only act (e.g., "Constitución", "Disolución")
 type BormeActoTexto struct {
-	Name  string   `json:"name"`
-	Value *string  `json:"value,omitempty"`
+	Name  string  `json:"name"`
+	Value *string `json:"value,omitempty"`
+	Traceable
 }
 
-func (a *BormeActoTexto) GetName() string   { return a.Name }
+func (a *BormeActoTexto) GetName() string { return a.Name }
 func (a *BormeActoTexto) GetValue() interface{} {
 	if a.Value == nil {
 		return nil
@@ -107,36 +148,86 @@ func (a *BormeActoTexto) GetValue() interface{} {
 	return *a.Value
 }
 
+// Cargo represents a cargo type and its holders, in the order they appear
+// in the bulletin (e.g., "Adm. Solid.: RAMA SANCHEZ JOSE PEDRO;RAMA SANCHEZ JAVIER")
+type Cargo struct {
+	Name      string     `json:"name"`
+	Canonical *CargoInfo `json:"canonical,omitempty"` // nil for unlisted abbreviations
+	Holders   []Holder   `json:"holders"`
+}
+
+// HolderNames returns the names of the cargo holders
+func (c Cargo) HolderNames() []string {
+	names := make([]string, 0, len(c.Holders))
+	for _, h := range c.Holders {
+		names = append(names, h.Name)
+	}
+	return names
+}
+
+// Categoria returns the cargo category, or "" if the cargo is not in the catalogue
+func (c Cargo) Categoria() CargoCategoria {
+	if c.Canonical == nil {
+		return ""
+	}
+	return c.Canonical.Categoria
+}
+
 // BormeActoCargo represents an act with cargo assignments (e.g., "Nombramientos", "Ceses")
 type BormeActoCargo struct {
-	Name  string              `json:"name"`
-	Value map[string][]string `json:"value"` // cargo type -> list of person names
+	Name  string  `json:"name"`
+	Value []Cargo `json:"value"` // cargos in order of appearance
+	Traceable
 }
 
 func (a *BormeActoCargo) GetName() string   { return a.Name }
 func (a *BormeActoCargo) GetValue() interface{} { return a.Value }
 
-// GetNombresCargos returns the cargo types (keys of the value map)
+// GetNombresCargos returns the cargo types in order of appearance
 func (a *BormeActoCargo) GetNombresCargos() []string {
 	if a.Value == nil {
 		return nil
 	}
-	keys := make([]string, 0, len(a.Value))
-	for k := range a.Value {
-		keys = append(keys, k)
+	names := make([]string, 0, len(a.Value))
+	for _, c := range a.Value {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// FilterCategoria returns the cargos of the given category (e.g. all directors)
+func (a *BormeActoCargo) FilterCategoria(categoria CargoCategoria) []Cargo {
+	var result []Cargo
+	for _, c := range a.Value {
+		if c.Categoria() == categoria {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// GetCargo returns the cargo with the given name, or nil if not present
+func (a *BormeActoCargo) GetCargo(name string) *Cargo {
+	for i := range a.Value {
+		if a.Value[i].Name == name {
+			return &a.Value[i]
+		}
 	}
-	return keys
+	return nil
 }
 
 // BormeAnuncio represents a single announcement in the BORME
 type BormeAnuncio struct {
-	ID              int          `json:"id"`
-	Empresa         string       `json:"empresa"`
-	Registro        string       `json:"registro,omitempty"`
-	Sucursal        bool         `json:"sucursal,omitempty"`
-	Liquidacion     bool         `json:"liquidacion,omitempty"`
-	DatosRegistrales string      `json:"datos_registrales,omitempty"`
-	Actos           []BormeActo  `json:"actos"`
+	ID                 int          `json:"id"`
+	Empresa            string       `json:"empresa"`                       // as published
+	EmpresaNormalizada string       `json:"empresa_normalizada,omitempty"` // see regex.NormalizeEmpresa
+	Registro           string       `json:"registro,omitempty"`
+	Sucursal           bool         `json:"sucursal,omitempty"`
+	Liquidacion        bool         `json:"liquidacion,omitempty"`
+	DatosRegistrales   string       `json:"datos_registrales,omitempty"`
+	Actos              []BormeActo  `json:"actos"`
+	Correcciones       []Correccion `json:"correcciones,omitempty"` // see actos.ApplyErratas
+	Traceable
 }
 
 func (a *BormeAnuncio) GetBormeActos() []BormeActo {
@@ -149,14 +240,17 @@ func (a *BormeAnuncio) String() string {
 
 // Borme represents a complete BORME bulletin
 type Borme struct {
-	Date           time.Time      `json:"date"`
-	Seccion        Seccion        `json:"seccion"`
-	Provincia      *Provincia     `json:"provincia,omitempty"`
-	Num            int            `json:"num"`
-	CVE            string         `json:"cve,omitempty"`
-	Filename       *string        `json:"filename,omitempty"`
-	Anuncios       map[int]*BormeAnuncio `json:"anuncios"`
-	AnunciosRango [2]int         `json:"anuncios_rango,omitempty"`
+	Date          time.Time             `json:"date"`
+	Seccion       Seccion               `json:"seccion"`
+	Subseccion    Subseccion            `json:"subseccion,omitempty"`
+	Provincia     *Provincia            `json:"provincia,omitempty"`
+	Num           int                   `json:"num"`
+	CVE           CVE                   `json:"cve,omitzero"`
+	Filename      *string               `json:"filename,omitempty"`
+	Anuncios      map[int]*BormeAnuncio `json:"anuncios"`
+	Duplicados    []*BormeAnuncio       `json:"duplicados,omitempty"` // anuncios repeating a number already in Anuncios
+	AnunciosRango [2]int                `json:"anuncios_rango,omitempty"`
+	Diagnostics   Diagnostics           `json:"diagnostics,omitempty"`
 }
 
 // NewBorme creates a new Borme instance
@@ -170,9 +264,14 @@ func NewBorme(date time.Time, seccion Seccion, provincia *Provincia, num int) *B
 	}
 }
 
-// SetCVE sets the CVE (Código de Verificación Electrónica)
-func (b *Borme) SetCVE(cve string) {
-	b.CVE = cve
+// SetCVE parses and sets the CVE (Código de Verificación Electrónica)
+func (b *Borme) SetCVE(cve string) error {
+	c, err := ParseCVE(cve)
+	if err != nil {
+		return err
+	}
+	b.CVE = c
+	return nil
 }
 
 // SetFilename sets the source filename
@@ -180,6 +279,11 @@ func (b *Borme) SetFilename(filename string) {
 	b.Filename = &filename
 }
 
+// AddDiagnostic records a problem found while parsing the bulletin
+func (b *Borme) AddDiagnostic(d Diagnostic) {
+	b.Diagnostics = append(b.Diagnostics, d)
+}
+
 // AddAnuncio adds an announcement to the bulletin
 func (b *Borme) AddAnuncio(a *BormeAnuncio) {
 	b.Anuncios[a.ID] = a
@@ -190,6 +294,50 @@ func (b *Borme) SetAnunciosRango(minID, maxID int) {
 	b.AnunciosRango = [2]int{minID, maxID}
 }
 
+// AnunciosOrdenados returns the anuncios of the bulletin by number
+func (b *Borme) AnunciosOrdenados() []*BormeAnuncio {
+	anuncios := make([]*BormeAnuncio, 0, len(b.Anuncios))
+	for _, a := range b.Anuncios {
+		anuncios = append(anuncios, a)
+	}
+	sort.Slice(anuncios, func(i, j int) bool { return anuncios[i].ID < anuncios[j].ID })
+	return anuncios
+}
+
+// RangoSolapado reports two bulletins whose anuncio ranges overlap
+type RangoSolapado struct {
+	A *Borme
+	B *Borme
+}
+
+// FindRangosSolapados returns the bulletins whose AnunciosRango overlap.
+// Anuncio numbers are sequential across all provinces within a year, so
+// two bulletins of the same year must never share a number.
+func FindRangosSolapados(bormes []*Borme) []RangoSolapado {
+	sorted := make([]*Borme, 0, len(bormes))
+	for _, b := range bormes {
+		if b.AnunciosRango != [2]int{} {
+			sorted = append(sorted, b)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].AnunciosRango[0] < sorted[j].AnunciosRango[0]
+	})
+
+	var result []RangoSolapado
+	for i, a := range sorted {
+		for _, b := range sorted[i+1:] {
+			if b.AnunciosRango[0] > a.AnunciosRango[1] {
+				break
+			}
+			if a.Date.Year() == b.Date.Year() {
+				result = append(result, RangoSolapado{A: a, B: b})
+			}
+		}
+	}
+	return result
+}
+
 // BormeToJSON serializes Borme to JSON
 func BormeToJSON(b *Borme, pretty bool) ([]byte, error) {
 	if pretty {
@@ -0,0 +1,262 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// CargoCategoria groups cargos by the role they play in the company
+type CargoCategoria string
+
+const (
+	CargoCategoriaAdministracion CargoCategoria = "administracion"
+	CargoCategoriaApoderamiento  CargoCategoria = "apoderamiento"
+	CargoCategoriaAuditoria      CargoCategoria = "auditoria"
+	CargoCategoriaLiquidacion    CargoCategoria = "liquidacion"
+	CargoCategoriaSocio          CargoCategoria = "socio"
+)
+
+// EntityKind tells natural persons and legal entities apart
+type EntityKind string
+
+const (
+	EntityPersona EntityKind = "persona"
+	EntityEmpresa EntityKind = "empresa"
+)
+
+// FormaJuridica is the legal form of a company, as detected from its name
+type FormaJuridica string
+
+const (
+	FormaJuridicaSL    FormaJuridica = "SL"
+	FormaJuridicaSLU   FormaJuridica = "SLU"
+	FormaJuridicaSLL   FormaJuridica = "SLL"
+	FormaJuridicaSLP   FormaJuridica = "SLP"
+	FormaJuridicaSLNE  FormaJuridica = "SLNE"
+	FormaJuridicaSA    FormaJuridica = "SA"
+	FormaJuridicaSAU   FormaJuridica = "SAU"
+	FormaJuridicaSAL   FormaJuridica = "SAL"
+	FormaJuridicaSCOOP FormaJuridica = "SCOOP"
+	FormaJuridicaAIE   FormaJuridica = "AIE"
+	FormaJuridicaSC    FormaJuridica = "SC"
+	FormaJuridicaCB    FormaJuridica = "CB"
+
+	// Foreign legal forms
+	FormaJuridicaGMBH FormaJuridica = "GMBH"
+	FormaJuridicaAG   FormaJuridica = "AG"
+	FormaJuridicaLTD  FormaJuridica = "LTD"
+	FormaJuridicaPLC  FormaJuridica = "PLC"
+	FormaJuridicaLLC  FormaJuridica = "LLC"
+	FormaJuridicaINC  FormaJuridica = "INC"
+	FormaJuridicaBV   FormaJuridica = "BV"
+	FormaJuridicaNV   FormaJuridica = "NV"
+	FormaJuridicaSARL FormaJuridica = "SARL"
+	FormaJuridicaSAS  FormaJuridica = "SAS"
+	FormaJuridicaSPA  FormaJuridica = "SPA"
+	FormaJuridicaSRL  FormaJuridica = "SRL"
+	FormaJuridicaLDA  FormaJuridica = "LDA"
+	FormaJuridicaAB   FormaJuridica = "AB"
+)
+
+// Holder is a person or company holding a cargo
+type Holder struct {
+	Name          string         `json:"name"`
+	Kind          EntityKind     `json:"kind"`
+	FormaJuridica FormaJuridica  `json:"forma_juridica,omitempty"`
+	Representante string         `json:"representante,omitempty"` // natural person acting for a company holder
+	Persona       *NombrePersona `json:"persona,omitempty"`       // normalised name of a natural person
+}
+
+// IsEmpresa returns true if the holder is a legal entity
+func (h Holder) IsEmpresa() bool {
+	return h.Kind == EntityEmpresa
+}
+
+// CargoInfo describes a canonical cargo
+type CargoInfo struct {
+	ID        string         `json:"id"`
+	Nombre    string         `json:"nombre"`
+	NameEN    string         `json:"name_en"`
+	Categoria CargoCategoria `json:"categoria"`
+}
+
+// Cargos is the cargo catalogue, keyed by canonical ID
+var Cargos = map[string]CargoInfo{
+	// Administración
+	"administrador_unico":            {"administrador_unico", "Administrador único", "Sole director", CargoCategoriaAdministracion},
+	"administrador_solidario":        {"administrador_solidario", "Administrador solidario", "Joint and several director", CargoCategoriaAdministracion},
+	"administrador_mancomunado":      {"administrador_mancomunado", "Administrador mancomunado", "Joint director", CargoCategoriaAdministracion},
+	"administrador_provisional":      {"administrador_provisional", "Administrador provisional", "Interim director", CargoCategoriaAdministracion},
+	"administrador":                  {"administrador", "Administrador", "Director", CargoCategoriaAdministracion},
+	"consejero":                      {"consejero", "Consejero", "Board member", CargoCategoriaAdministracion},
+	"consejero_independiente":        {"consejero_independiente", "Consejero independiente", "Independent board member", CargoCategoriaAdministracion},
+	"consejero_dominical":            {"consejero_dominical", "Consejero dominical", "Proprietary board member", CargoCategoriaAdministracion},
+	"consejero_ejecutivo":            {"consejero_ejecutivo", "Consejero ejecutivo", "Executive board member", CargoCategoriaAdministracion},
+	"consejero_coordinador":          {"consejero_coordinador", "Consejero coordinador", "Lead independent director", CargoCategoriaAdministracion},
+	"consejero_delegado":             {"consejero_delegado", "Consejero delegado", "Managing director", CargoCategoriaAdministracion},
+	"consejero_delegado_mancomunado": {"consejero_delegado_mancomunado", "Consejero delegado mancomunado", "Joint managing director", CargoCategoriaAdministracion},
+	"consejero_delegado_solidario":   {"consejero_delegado_solidario", "Consejero delegado solidario", "Joint and several managing director", CargoCategoriaAdministracion},
+	"presidente":                     {"presidente", "Presidente", "Chairman", CargoCategoriaAdministracion},
+	"presidente_consejo":             {"presidente_consejo", "Presidente del consejo de administración", "Chairman of the board", CargoCategoriaAdministracion},
+	"vicepresidente":                 {"vicepresidente", "Vicepresidente", "Vice-chairman", CargoCategoriaAdministracion},
+	"secretario":                     {"secretario", "Secretario", "Secretary", CargoCategoriaAdministracion},
+	"secretario_consejero":           {"secretario_consejero", "Secretario consejero", "Secretary and board member", CargoCategoriaAdministracion},
+	"secretario_no_consejero":        {"secretario_no_consejero", "Secretario no consejero", "Non-member secretary", CargoCategoriaAdministracion},
+	"vicesecretario":                 {"vicesecretario", "Vicesecretario", "Vice-secretary", CargoCategoriaAdministracion},
+	"miembro_comision_ejecutiva":     {"miembro_comision_ejecutiva", "Miembro de la comisión ejecutiva", "Executive committee member", CargoCategoriaAdministracion},
+	"presidente_comision_ejecutiva":  {"presidente_comision_ejecutiva", "Presidente de la comisión ejecutiva", "Executive committee chairman", CargoCategoriaAdministracion},
+	"secretario_comision_ejecutiva":  {"secretario_comision_ejecutiva", "Secretario de la comisión ejecutiva", "Executive committee secretary", CargoCategoriaAdministracion},
+	"miembro_comision_auditoria":     {"miembro_comision_auditoria", "Miembro de la comisión de auditoría", "Audit committee member", CargoCategoriaAdministracion},
+	"presidente_comision_auditoria":  {"presidente_comision_auditoria", "Presidente de la comisión de auditoría", "Audit committee chairman", CargoCategoriaAdministracion},
+	"miembro_junta_directiva":        {"miembro_junta_directiva", "Miembro de la junta directiva", "Governing board member", CargoCategoriaAdministracion},
+	"director_general":               {"director_general", "Director general", "General manager", CargoCategoriaAdministracion},
+	"director_gerente":               {"director_gerente", "Director gerente", "Managing director", CargoCategoriaAdministracion},
+	"gerente":                        {"gerente", "Gerente", "Manager", CargoCategoriaAdministracion},
+	"tesorero":                       {"tesorero", "Tesorero", "Treasurer", CargoCategoriaAdministracion},
+	"vocal":                          {"vocal", "Vocal", "Board member", CargoCategoriaAdministracion},
+	"representante":                  {"representante", "Representante", "Representative", CargoCategoriaAdministracion},
+	"representante_permanente":       {"representante_permanente", "Representante permanente", "Permanent representative", CargoCategoriaAdministracion},
+	"representante_143_rrm":          {"representante_143_rrm", "Representante (art. 143 RRM)", "Representative (art. 143 RRM)", CargoCategoriaAdministracion},
+	"entidad_depositaria":            {"entidad_depositaria", "Entidad depositaria", "Depositary", CargoCategoriaAdministracion},
+	"patrono":                        {"patrono", "Patrono", "Trustee", CargoCategoriaAdministracion},
+
+	// Apoderamiento
+	"apoderado":                       {"apoderado", "Apoderado", "Attorney-in-fact", CargoCategoriaApoderamiento},
+	"apoderado_solidario":             {"apoderado_solidario", "Apoderado solidario", "Joint and several attorney-in-fact", CargoCategoriaApoderamiento},
+	"apoderado_mancomunado":           {"apoderado_mancomunado", "Apoderado mancomunado", "Joint attorney-in-fact", CargoCategoriaApoderamiento},
+	"apoderado_mancomunado_solidario": {"apoderado_mancomunado_solidario", "Apoderado mancomunado y solidario", "Joint or several attorney-in-fact", CargoCategoriaApoderamiento},
+
+	// Auditoría
+	"auditor":                               {"auditor", "Auditor", "Auditor", CargoCategoriaAuditoria},
+	"auditor_suplente":                      {"auditor_suplente", "Auditor suplente", "Substitute auditor", CargoCategoriaAuditoria},
+	"auditor_cuentas_consolidadas":          {"auditor_cuentas_consolidadas", "Auditor de cuentas consolidadas", "Consolidated accounts auditor", CargoCategoriaAuditoria},
+	"auditor_suplente_cuentas_consolidadas": {"auditor_suplente_cuentas_consolidadas", "Auditor suplente de cuentas consolidadas", "Substitute consolidated accounts auditor", CargoCategoriaAuditoria},
+
+	// Liquidación
+	"liquidador":              {"liquidador", "Liquidador", "Liquidator", CargoCategoriaLiquidacion},
+	"liquidador_mancomunado":  {"liquidador_mancomunado", "Liquidador mancomunado", "Joint liquidator", CargoCategoriaLiquidacion},
+	"liquidador_solidario":    {"liquidador_solidario", "Liquidador solidario", "Joint and several liquidator", CargoCategoriaLiquidacion},
+	"administrador_concursal": {"administrador_concursal", "Administrador concursal", "Insolvency administrator", CargoCategoriaLiquidacion},
+
+	// Socios
+	"socio_unico":       {"socio_unico", "Socio único", "Sole shareholder", CargoCategoriaSocio},
+	"socio_profesional": {"socio_profesional", "Socio profesional", "Professional partner", CargoCategoriaSocio},
+	"socio":             {"socio", "Socio", "Partner", CargoCategoriaSocio},
+}
+
+// CargoAbreviaturas maps every abbreviation published in BORME to its canonical cargo ID
+var CargoAbreviaturas = map[string]string{
+	"Adm. Unico":            "administrador_unico",
+	"Adm. Único":            "administrador_unico",
+	"Adm.Unico":             "administrador_unico",
+	"Adm. Solid.":           "administrador_solidario",
+	"Adm.Solid.":            "administrador_solidario",
+	"Adm. Mancom.":          "administrador_mancomunado",
+	"Adm.Mancom.":           "administrador_mancomunado",
+	"Adm.Manc.":             "administrador_mancomunado",
+	"Adm.Provis.":           "administrador_provisional",
+	"Administrador":         "administrador",
+	"Consejero":             "consejero",
+	"Cons.Ind.":             "consejero_independiente",
+	"Consj.Indep.":          "consejero_independiente",
+	"Cons.Dominical":        "consejero_dominical",
+	"Cons.Ej.":              "consejero_ejecutivo",
+	"Consejero Coordinador": "consejero_coordinador",
+	"Con.Delegado":          "consejero_delegado",
+	"Cons.Del.Man":          "consejero_delegado_mancomunado",
+	"Cons.Del.Mancom":       "consejero_delegado_mancomunado",
+	"Cons.Del.Sol":          "consejero_delegado_solidario",
+	"Presidente":            "presidente",
+	"Pres.Consejo":          "presidente_consejo",
+	"Presid.C.Adm.":         "presidente_consejo",
+	"Vicepresid.":           "vicepresidente",
+	"Vicepresidente":        "vicepresidente",
+	"Secretario":            "secretario",
+	"Secr.Cons.":            "secretario_consejero",
+	"Secr.no Cons":          "secretario_no_consejero",
+	"Vicesecret.":           "vicesecretario",
+	"Vsecr.Consj.":          "vicesecretario",
+	"Miem.Com.Ej.":          "miembro_comision_ejecutiva",
+	"Pres.Com.Ej.":          "presidente_comision_ejecutiva",
+	"Secr.Com.Ej.":          "secretario_comision_ejecutiva",
+	"Miem.Com.Aud":          "miembro_comision_auditoria",
+	"Pres.Com.Aud":          "presidente_comision_auditoria",
+	"Mbro.Junta Dir":        "miembro_junta_directiva",
+	"Director Gral":         "director_general",
+	"Director Gerente":      "director_gerente",
+	"Gerente":               "gerente",
+	"Tesorero":              "tesorero",
+	"Vocal":                 "vocal",
+	"Representante":         "representante",
+	"Representan":           "representante",
+	"Rep.Permanente":        "representante_permanente",
+	"Repr.143 RRM":          "representante_143_rrm",
+	"Entid.Deposit.":        "entidad_depositaria",
+	"Entidad Depositaria":   "entidad_depositaria",
+	"Patrono":               "patrono",
+
+	"Apoderado":     "apoderado",
+	"Apoder.Solid.": "apoderado_solidario",
+	"Apod.Solid.":   "apoderado_solidario",
+	"Apo.Sol.":      "apoderado_solidario",
+	"Apo.Manc.":     "apoderado_mancomunado",
+	"Apod.Manc.":    "apoderado_mancomunado",
+	"Apoder.Manc.":  "apoderado_mancomunado",
+	"Apo.Man.Soli":  "apoderado_mancomunado_solidario",
+	"Apo.Sol.Man.":  "apoderado_mancomunado_solidario",
+
+	"Auditor":          "auditor",
+	"Aud.Supl.":        "auditor_suplente",
+	"Auditor Suplente": "auditor_suplente",
+	"Aud.C.Con.":       "auditor_cuentas_consolidadas",
+	"Aud.Cta.Con.":     "auditor_cuentas_consolidadas",
+	"Aud.Supl.C.C":     "auditor_suplente_cuentas_consolidadas",
+
+	"Liquidador":             "liquidador",
+	"Liquidador M.":          "liquidador_mancomunado",
+	"Liquidador Mancomunado": "liquidador_mancomunado",
+	"LiqMan":                 "liquidador_mancomunado",
+	"Liquidador S.":          "liquidador_solidario",
+	"Liquidador Solidario":   "liquidador_solidario",
+	"LiqSoli":                "liquidador_solidario",
+	"Adm.Concursal":          "administrador_concursal",
+	"Adm. Concursal":         "administrador_concursal",
+	"Adm.Conc.":              "administrador_concursal",
+
+	"Socio único": "socio_unico",
+	"Socio Unico": "socio_unico",
+	"Socio Prof.": "socio_profesional",
+	"Soc.Prof.":   "socio_profesional",
+	"Socio":       "socio",
+}
+
+// LookupCargo returns the canonical cargo for an abbreviation (case-insensitive)
+func LookupCargo(abbr string) (CargoInfo, bool) {
+	abbr = strings.TrimSpace(abbr)
+	id, ok := CargoAbreviaturas[abbr]
+	if !ok {
+		for k, v := range CargoAbreviaturas {
+			if strings.EqualFold(k, abbr) {
+				id, ok = v, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return CargoInfo{}, false
+	}
+	info, ok := Cargos[id]
+	return info, ok
+}
+
+// CargosByCategoria returns the canonical cargos of a category, sorted by ID
+func CargosByCategoria(categoria CargoCategoria) []CargoInfo {
+	var result []CargoInfo
+	for _, info := range Cargos {
+		if info.Categoria == categoria {
+			result = append(result, info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
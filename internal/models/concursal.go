@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// FaseConcursal is the phase of an insolvency procedure
+type FaseConcursal string
+
+const (
+	FaseDeclaracion FaseConcursal = "declaracion" // declaración de concurso
+	FaseConvenio    FaseConcursal = "convenio"
+	FaseLiquidacion FaseConcursal = "liquidacion"
+	FaseConclusion  FaseConcursal = "conclusion"
+)
+
+// BormeActoConcursal is an insolvency acto (Situación concursal, Declaración
+// de concurso, Apertura de fase de liquidación, Resoluciones judiciales)
+type BormeActoConcursal struct {
+	Name            string        `json:"name"`
+	Texto           string        `json:"texto"` // as published
+	Juzgado         string        `json:"juzgado,omitempty"`
+	Juez            string        `json:"juez,omitempty"`
+	Procedimiento   string        `json:"procedimiento,omitempty"` // e.g. "123/2015"
+	Fase            FaseConcursal `json:"fase,omitempty"`
+	FechaResolucion time.Time     `json:"fecha_resolucion,omitzero"`
+	Administradores []string      `json:"administradores,omitempty"` // administradores concursales
+	Traceable
+}
+
+func (a *BormeActoConcursal) GetName() string       { return a.Name }
+func (a *BormeActoConcursal) GetValue() interface{} { return a.Texto }
+
+// AnuncioFilter selects anuncios of a bulletin
+type AnuncioFilter func(a *BormeAnuncio) bool
+
+// IsConcursal reports whether the anuncio has an insolvency acto
+func IsConcursal(a *BormeAnuncio) bool {
+	for _, acto := range a.Actos {
+		if _, ok := acto.(*BormeActoConcursal); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterAnuncios removes the anuncios not selected by keep. AnunciosRango
+// keeps the range published in the bulletin.
+func (b *Borme) FilterAnuncios(keep AnuncioFilter) {
+	for id, a := range b.Anuncios {
+		if !keep(a) {
+			delete(b.Anuncios, id)
+		}
+	}
+
+	duplicados := b.Duplicados[:0]
+	for _, a := range b.Duplicados {
+		if keep(a) {
+			duplicados = append(duplicados, a)
+		}
+	}
+	b.Duplicados = duplicados
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Capital is an amount of share capital, e.g. "3.000,00 Euros"
+type Capital struct {
+	Importe float64 `json:"importe"`
+	Moneda  string  `json:"moneda,omitempty"` // as published, e.g. "Euros"
+	Texto   string  `json:"texto"`            // as published
+}
+
+// Domicilio is a registered address, e.g. "C/ MAYOR 1 (BARCELONA)"
+type Domicilio struct {
+	Direccion string `json:"direccion"`
+	Municipio string `json:"municipio,omitempty"` // between parentheses
+	Texto     string `json:"texto"`               // as published
+}
+
+// BormeActoConstitucion is a Constitución acto. Cargos holds the initial
+// appointments, whether published in the acto text or in the Nombramientos
+// acto that follows it.
+type BormeActoConstitucion struct {
+	Name                string        `json:"name"`
+	Texto               string        `json:"texto"` // as published
+	ComienzoOperaciones time.Time     `json:"comienzo_operaciones,omitzero"`
+	ObjetoSocial        *ObjetoSocial `json:"objeto_social,omitempty"`
+	Domicilio           *Domicilio    `json:"domicilio,omitempty"`
+	Capital             *Capital      `json:"capital,omitempty"`
+	Cargos              []Cargo       `json:"cargos,omitempty"`
+	Traceable
+}
+
+func (a *BormeActoConstitucion) GetName() string       { return a.Name }
+func (a *BormeActoConstitucion) GetValue() interface{} { return a.Texto }
+
+// Constitucion returns the Constitución acto of the anuncio, or nil
+func (a *BormeAnuncio) Constitucion() *BormeActoConstitucion {
+	for _, acto := range a.Actos {
+		if c, ok := acto.(*BormeActoConstitucion); ok {
+			return c
+		}
+	}
+	return nil
+}
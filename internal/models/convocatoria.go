@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// TipoJunta is the kind of general meeting called by a convocatoria
+type TipoJunta string
+
+const (
+	JuntaOrdinaria               TipoJunta = "ordinaria"
+	JuntaExtraordinaria          TipoJunta = "extraordinaria"
+	JuntaOrdinariaExtraordinaria TipoJunta = "ordinaria_extraordinaria" // both, in the same session
+)
+
+// Convocatoria is the call of a junta general published in Section C.
+// Dates and times are local, as published.
+type Convocatoria struct {
+	TipoJunta           TipoJunta `json:"tipo_junta,omitempty"`
+	PrimeraConvocatoria time.Time `json:"primera_convocatoria,omitzero"`
+	SegundaConvocatoria time.Time `json:"segunda_convocatoria,omitzero"`
+	Lugar               string    `json:"lugar,omitempty"`         // venue address
+	OrdenDelDia         []string  `json:"orden_del_dia,omitempty"` // agenda items
+	Convocante          string    `json:"convocante,omitempty"`    // convening body, e.g. "Consejo de Administración"
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// BOE URLs of a document by its CVE
+const (
+	cveURLPDF  = "https://www.boe.es/borme/dias/%04d/%02d/%02d/pdfs/%s.pdf"
+	cveURLXML  = "https://www.boe.es/diario_borme/xml.php?id=%s"
+	cveURLHTML = "https://www.boe.es/diario_borme/txt.php?id=%s"
+)
+
+// reCVE matches "BORME-A-2015-101-28" (Sections A and B) and "BORME-C-2011-20488" (Section C)
+var reCVE = regexp.MustCompile(`^BORME-([ABC])-(\d{4})-(\d+)(?:-(\d+))?$`)
+
+// CVE is the Código de Verificación Electrónica of a BORME document. Section
+// A and B bulletins are identified by year, bulletin number (NBO) and INE
+// province code ("BORME-A-2015-101-28"), Section C anuncios by year and
+// anuncio number ("BORME-C-2011-20488").
+type CVE struct {
+	Seccion   Seccion
+	Year      int
+	NBO       int // Sections A and B
+	Provincia int // INE province code, Sections A and B
+	Anuncio   int // Section C
+}
+
+// ParseCVE parses and validates a CVE like "BORME-A-2015-101-28"
+func ParseCVE(s string) (CVE, error) {
+	m := reCVE.FindStringSubmatch(s)
+	if m == nil {
+		return CVE{}, fmt.Errorf("invalid CVE: %q", s)
+	}
+
+	c := CVE{Seccion: Seccion(m[1])}
+	c.Year, _ = strconv.Atoi(m[2])
+	n, _ := strconv.Atoi(m[3])
+
+	if c.Seccion == SeccionC {
+		if m[4] != "" {
+			return CVE{}, fmt.Errorf("invalid CVE %q: Section C CVEs have no province", s)
+		}
+		c.Anuncio = n
+	} else {
+		if m[4] == "" {
+			return CVE{}, fmt.Errorf("invalid CVE %q: missing province", s)
+		}
+		c.NBO = n
+		c.Provincia, _ = strconv.Atoi(m[4])
+	}
+
+	if err := c.Validate(); err != nil {
+		return CVE{}, err
+	}
+	return c, nil
+}
+
+// Validate checks the CVE parts
+func (c CVE) Validate() error {
+	switch c.Seccion {
+	case SeccionA, SeccionB:
+		if c.NBO < 1 {
+			return fmt.Errorf("invalid CVE %s: bulletin number must be positive", c)
+		}
+		if ProvinciaFromINE(c.Provincia) == nil {
+			return fmt.Errorf("invalid CVE %s: unknown province code %d", c, c.Provincia)
+		}
+	case SeccionC:
+		if c.Anuncio < 1 {
+			return fmt.Errorf("invalid CVE %s: anuncio number must be positive", c)
+		}
+	default:
+		return fmt.Errorf("invalid CVE section: %q", c.Seccion)
+	}
+	if c.Year < 1000 || c.Year > 9999 {
+		return fmt.Errorf("invalid CVE %s: invalid year", c)
+	}
+	return nil
+}
+
+// IsZero reports whether the CVE is unset
+func (c CVE) IsZero() bool {
+	return c == CVE{}
+}
+
+// String returns the CVE in its published form
+func (c CVE) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	if c.Seccion == SeccionC {
+		return fmt.Sprintf("BORME-C-%d-%d", c.Year, c.Anuncio)
+	}
+	return fmt.Sprintf("BORME-%s-%d-%d-%02d", c.Seccion, c.Year, c.NBO, c.Provincia)
+}
+
+// Filename returns the archive filename for the document with the given
+// extension (e.g. ".pdf" gives "BORME-A-2015-101-28.pdf")
+func (c CVE) Filename(ext string) string {
+	return c.String() + ext
+}
+
+// URLPDF returns the URL of the PDF. PDFs are stored by publication date,
+// which is not part of the CVE.
+func (c CVE) URLPDF(date time.Time) string {
+	return fmt.Sprintf(cveURLPDF, date.Year(), int(date.Month()), date.Day(), c)
+}
+
+// URLXML returns the URL of the XML document
+func (c CVE) URLXML() string {
+	return fmt.Sprintf(cveURLXML, c)
+}
+
+// URLHTML returns the URL of the HTML document
+func (c CVE) URLHTML() string {
+	return fmt.Sprintf(cveURLHTML, c)
+}
+
+// MarshalText encodes the CVE as its published form, so it serializes to a
+// JSON string
+func (c CVE) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText parses a CVE; an empty string gives the zero CVE
+func (c *CVE) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = CVE{}
+		return nil
+	}
+	parsed, err := ParseCVE(string(data))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
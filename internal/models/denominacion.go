@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// BormeActoDenominacion is a Cambio de denominación social acto. The
+// anuncio is published under the previous name.
+type BormeActoDenominacion struct {
+	Name                string `json:"name"`
+	Anterior            string `json:"anterior"`
+	AnteriorNormalizada string `json:"anterior_normalizada"` // see regex.NormalizeEmpresa
+	Nueva               string `json:"nueva"`
+	NuevaNormalizada    string `json:"nueva_normalizada"`
+	Traceable
+}
+
+func (a *BormeActoDenominacion) GetName() string       { return a.Name }
+func (a *BormeActoDenominacion) GetValue() interface{} { return a.Nueva }
+
+// CambioDenominacion is a company rename published in a bulletin
+type CambioDenominacion struct {
+	Date      time.Time `json:"date"`
+	AnuncioID int       `json:"anuncio_id"`
+	Anterior  string    `json:"anterior"`
+	Nueva     string    `json:"nueva"`
+}
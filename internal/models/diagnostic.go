@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the severity of a parse diagnostic
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"    // unusual but handled, e.g. an unlisted cargo
+	SeverityWarning Severity = "warning" // part of the input was skipped or may be wrong
+	SeverityError   Severity = "error"   // the bulletin could not be parsed
+)
+
+// Diagnostic reports a problem found while parsing a bulletin
+type Diagnostic struct {
+	Severity  Severity `json:"severity"`
+	Page      int      `json:"page,omitempty"`       // 1-based, 0 if unknown
+	AnuncioID int      `json:"anuncio_id,omitempty"` // 0 outside anuncios
+	Message   string   `json:"message"`
+	Snippet   string   `json:"snippet,omitempty"` // raw text that caused it
+}
+
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	b.WriteString(string(d.Severity))
+	if d.Page != 0 {
+		fmt.Fprintf(&b, " page %d", d.Page)
+	}
+	if d.AnuncioID != 0 {
+		fmt.Fprintf(&b, " anuncio %d", d.AnuncioID)
+	}
+	b.WriteString(": ")
+	b.WriteString(d.Message)
+	if d.Snippet != "" {
+		fmt.Fprintf(&b, " (%q)", d.Snippet)
+	}
+	return b.String()
+}
+
+// Diagnostics is the list of diagnostics of a parse result
+type Diagnostics []Diagnostic
+
+// HasErrors reports whether any diagnostic has error severity
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the diagnostics with at least the given severity
+func (ds Diagnostics) Filter(min Severity) Diagnostics {
+	var result Diagnostics
+	for _, d := range ds {
+		if severityRank[d.Severity] >= severityRank[min] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+var severityRank = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// DiagnosticsError is returned in strict mode when parsing produced
+// warnings or errors
+type DiagnosticsError struct {
+	Filename    string
+	Diagnostics Diagnostics
+}
+
+func (e *DiagnosticsError) Error() string {
+	if len(e.Diagnostics) == 1 {
+		return fmt.Sprintf("%s: %s", e.Filename, e.Diagnostics[0])
+	}
+	return fmt.Sprintf("%s: %d diagnostics, first: %s", e.Filename, len(e.Diagnostics), e.Diagnostics[0])
+}
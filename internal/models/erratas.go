@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ReferenciaAnuncio points to an anuncio published in an earlier bulletin
+type ReferenciaAnuncio struct {
+	Num       int       `json:"num,omitempty"` // bulletin number
+	Date      time.Time `json:"date,omitzero"`
+	CVE       CVE       `json:"cve,omitzero"`
+	AnuncioID int       `json:"anuncio_id,omitempty"`
+}
+
+// Year returns the year of the referenced bulletin, or 0 if unknown
+func (r ReferenciaAnuncio) Year() int {
+	if !r.Date.IsZero() {
+		return r.Date.Year()
+	}
+	return r.CVE.Year
+}
+
+// BormeActoFeDeErratas is a Fe de erratas acto correcting an anuncio
+// published earlier
+type BormeActoFeDeErratas struct {
+	Name       string            `json:"name"`
+	Texto      string            `json:"texto"` // as published
+	Referencia ReferenciaAnuncio `json:"referencia"`
+	Dice       string            `json:"dice,omitempty"`       // erroneous text
+	DebeDecir  string            `json:"debe_decir,omitempty"` // corrected text
+	Traceable
+}
+
+func (a *BormeActoFeDeErratas) GetName() string       { return a.Name }
+func (a *BormeActoFeDeErratas) GetValue() interface{} { return a.Referencia }
+
+// Correccion links an anuncio to a Fe de erratas published later, see
+// actos.ApplyErratas
+type Correccion struct {
+	Date      time.Time `json:"date"`       // date of the bulletin with the errata
+	Num       int       `json:"num"`        // number of the bulletin with the errata
+	AnuncioID int       `json:"anuncio_id"` // anuncio with the errata
+	Dice      string    `json:"dice,omitempty"`
+	DebeDecir string    `json:"debe_decir,omitempty"`
+	Aplicada  bool      `json:"aplicada"` // DebeDecir replaced Dice in the anuncio
+}
@@ -0,0 +1,12 @@
+package models
+
+// NombrePersona is the normalised name of a natural person published as
+// "RAMA SANCHEZ JOSE PEDRO", surnames first
+type NombrePersona struct {
+	Display         string `json:"display"` // e.g. "Jose Pedro Rama Sanchez"
+	Key             string `json:"key"`     // accent-folded match key, e.g. "RAMA SANCHEZ JOSE PEDRO"
+	PrimerApellido  string `json:"primer_apellido,omitempty"`
+	SegundoApellido string `json:"segundo_apellido,omitempty"`
+	Nombre          string `json:"nombre,omitempty"`          // given names
+	NombreConocido  bool   `json:"nombre_conocido,omitempty"` // the given names are in the bundled list
+}
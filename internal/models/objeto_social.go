@@ -0,0 +1,34 @@
+package models
+
+// ClasificacionCNAE is a CNAE-2009 activity assigned to an objeto social
+type ClasificacionCNAE struct {
+	Codigo      string  `json:"codigo"` // division or class code, e.g. "68" or "6832"
+	Descripcion string  `json:"descripcion"`
+	Seccion     string  `json:"seccion"`   // CNAE section letter, e.g. "L"
+	Confianza   float64 `json:"confianza"` // 0 to 1
+}
+
+// ObjetoSocial is the purpose of a company and its activity classification
+type ObjetoSocial struct {
+	Texto string              `json:"texto"`
+	CNAE  []ClasificacionCNAE `json:"cnae,omitempty"` // most likely first
+}
+
+// Principal returns the most likely classification, or nil if unclassified
+func (o *ObjetoSocial) Principal() *ClasificacionCNAE {
+	if o == nil || len(o.CNAE) == 0 {
+		return nil
+	}
+	return &o.CNAE[0]
+}
+
+// BormeActoObjetoSocial is a Cambio de objeto social or Ampliación del
+// objeto social acto
+type BormeActoObjetoSocial struct {
+	Name         string        `json:"name"`
+	ObjetoSocial *ObjetoSocial `json:"objeto_social"`
+	Traceable
+}
+
+func (a *BormeActoObjetoSocial) GetName() string       { return a.Name }
+func (a *BormeActoObjetoSocial) GetValue() interface{} { return a.ObjetoSocial.Texto }
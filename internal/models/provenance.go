@@ -0,0 +1,22 @@
+package models
+
+// Provenance locates a parsed element in the text extracted from the
+// source file, so it can be traced back to the PDF
+type Provenance struct {
+	Page    int    `json:"page,omitempty"`     // page where it starts, 0 if unknown
+	EndPage int    `json:"end_page,omitempty"` // page where it ends, if different
+	Start   int    `json:"start"`              // byte offset of the first line
+	End     int    `json:"end"`                // byte offset after the last line
+	Raw     string `json:"raw,omitempty"`      // source text, including any running headers
+}
+
+// Traceable is embedded by the parsed elements that can carry their Provenance
+type Traceable struct {
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
+
+// GetProvenance returns the provenance, or nil if it was not recorded
+func (t *Traceable) GetProvenance() *Provenance { return t.Provenance }
+
+// SetProvenance sets the provenance
+func (t *Traceable) SetProvenance(p *Provenance) { t.Provenance = p }
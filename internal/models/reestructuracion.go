@@ -0,0 +1,77 @@
+package models
+
+// TipoReestructuracion is the kind of a structural modification acto
+type TipoReestructuracion string
+
+const (
+	TipoFusion          TipoReestructuracion = "fusion"
+	TipoEscisionTotal   TipoReestructuracion = "escision_total"
+	TipoEscisionParcial TipoReestructuracion = "escision_parcial"
+	TipoCesionGlobal    TipoReestructuracion = "cesion_global"
+)
+
+// RolReestructuracion is the role of a company in a fusión, escisión or
+// cesión global de activo y pasivo
+type RolReestructuracion string
+
+const (
+	RolAbsorbente   RolReestructuracion = "absorbente"
+	RolAbsorbida    RolReestructuracion = "absorbida"
+	RolEscindida    RolReestructuracion = "escindida"
+	RolBeneficiaria RolReestructuracion = "beneficiaria"
+	RolCedente      RolReestructuracion = "cedente"
+	RolCesionaria   RolReestructuracion = "cesionaria"
+)
+
+// Participante is a company taking part in a structural modification
+type Participante struct {
+	Rol                RolReestructuracion `json:"rol"`
+	Empresa            string              `json:"empresa"`             // as published
+	EmpresaNormalizada string              `json:"empresa_normalizada"` // see regex.NormalizeEmpresa
+	Anuncio            bool                `json:"anuncio,omitempty"`   // the company of the anuncio
+}
+
+// BormeActoReestructuracion is a Fusión, Escisión or Cesión global de activo
+// y pasivo acto, with the companies involved and their roles
+type BormeActoReestructuracion struct {
+	Name          string               `json:"name"`
+	Tipo          TipoReestructuracion `json:"tipo"`
+	Texto         string               `json:"texto"` // as published
+	Participantes []Participante       `json:"participantes"`
+	Traceable
+}
+
+func (a *BormeActoReestructuracion) GetName() string       { return a.Name }
+func (a *BormeActoReestructuracion) GetValue() interface{} { return a.Participantes }
+
+// Empresas returns the names of the companies with the given role
+func (a *BormeActoReestructuracion) Empresas(rol RolReestructuracion) []string {
+	var empresas []string
+	for _, p := range a.Participantes {
+		if p.Rol == rol {
+			empresas = append(empresas, p.Empresa)
+		}
+	}
+	return empresas
+}
+
+// EmpresasRelacionadas returns the other companies taking part in the
+// fusiones, escisiones and cesiones globales of the anuncio, like
+// BormeC.EmpresasRelacionadas for Section C
+func (a *BormeAnuncio) EmpresasRelacionadas() []string {
+	var empresas []string
+	seen := make(map[string]bool)
+	for _, acto := range a.Actos {
+		r, ok := acto.(*BormeActoReestructuracion)
+		if !ok {
+			continue
+		}
+		for _, p := range r.Participantes {
+			if !p.Anuncio && !seen[p.EmpresaNormalizada] {
+				seen[p.EmpresaNormalizada] = true
+				empresas = append(empresas, p.Empresa)
+			}
+		}
+	}
+	return empresas
+}
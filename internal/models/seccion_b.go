@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TipoEntradaB is the kind of a Section B entry, given by its subsection heading
+type TipoEntradaB string
+
+const (
+	TipoEntradaBReduccionCapital  TipoEntradaB = "reduccion_capital"
+	TipoEntradaBAmpliacionCapital TipoEntradaB = "ampliacion_capital"
+	TipoEntradaBSociedadAbsorbida TipoEntradaB = "sociedad_absorbida"
+	TipoEntradaBDepositoCuentas   TipoEntradaB = "deposito_cuentas"
+	TipoEntradaBCierreHoja        TipoEntradaB = "cierre_hoja_registral"
+	TipoEntradaBCancelacionOficio TipoEntradaB = "cancelacion_oficio"
+	TipoEntradaBOtros             TipoEntradaB = "otros"
+)
+
+// BormeBEntrada represents an entry of Section B. Only the fields of its
+// subsection are set: Ejercicio for Depósitos de cuentas, Absorbente for
+// Sociedades absorbidas, Importe/CapitalResultante for capital changes.
+type BormeBEntrada struct {
+	ID                 int          `json:"id"`
+	Empresa            string       `json:"empresa"`
+	EmpresaNormalizada string       `json:"empresa_normalizada,omitempty"`
+	Registro           string       `json:"registro,omitempty"`
+	Tipo               TipoEntradaB `json:"tipo"`
+	Subseccion         string       `json:"subseccion"` // heading as published
+	Texto              string       `json:"texto,omitempty"`
+	Ejercicio          int          `json:"ejercicio,omitempty"`
+	Absorbente         string       `json:"absorbente,omitempty"`
+	Importe            float64      `json:"importe,omitempty"`
+	CapitalResultante  float64      `json:"capital_resultante,omitempty"`
+}
+
+// BormeB represents a Section B bulletin ("Otros actos publicados en el Registro Mercantil")
+type BormeB struct {
+	Date        time.Time       `json:"date"`
+	Seccion     Seccion         `json:"seccion"`
+	Subseccion  Subseccion      `json:"subseccion"`
+	Provincia   *Provincia      `json:"provincia,omitempty"`
+	Num         int             `json:"num"`
+	CVE         CVE             `json:"cve,omitzero"`
+	Filename    *string         `json:"filename,omitempty"`
+	Entradas    []BormeBEntrada `json:"entradas"`
+	Diagnostics Diagnostics     `json:"diagnostics,omitempty"`
+}
+
+// NewBormeB creates a new Section B bulletin
+func NewBormeB() *BormeB {
+	return &BormeB{
+		Seccion:    SeccionB,
+		Subseccion: SubseccionOtrosActos,
+		Entradas:   make([]BormeBEntrada, 0),
+	}
+}
+
+// AddDiagnostic records a problem found while parsing the bulletin
+func (b *BormeB) AddDiagnostic(d Diagnostic) {
+	b.Diagnostics = append(b.Diagnostics, d)
+}
+
+// EntradasByTipo returns the entries of the given kind
+func (b *BormeB) EntradasByTipo(tipo TipoEntradaB) []BormeBEntrada {
+	var result []BormeBEntrada
+	for _, e := range b.Entradas {
+		if e.Tipo == tipo {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// BormeBToJSON serializes BormeB to JSON
+func BormeBToJSON(b *BormeB, pretty bool) ([]byte, error) {
+	if pretty {
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, '\n')
+		return data, nil
+	}
+	return json.Marshal(b)
+}
@@ -0,0 +1,49 @@
+package models
+
+import "strings"
+
+// TipoAnuncioC is the category of a Section C announcement
+type TipoAnuncioC string
+
+const (
+	TipoCConvocatoriaJunta   TipoAnuncioC = "convocatoria_junta"
+	TipoCFusion              TipoAnuncioC = "fusion"
+	TipoCEscision            TipoAnuncioC = "escision"
+	TipoCReduccionCapital    TipoAnuncioC = "reduccion_capital"
+	TipoCTransformacion      TipoAnuncioC = "transformacion"
+	TipoCDisolucion          TipoAnuncioC = "disolucion" // disolución or liquidación
+	TipoCCambioDomicilio     TipoAnuncioC = "cambio_domicilio"
+	TipoCPerdidaCertificados TipoAnuncioC = "perdida_certificados"
+	TipoCOtros               TipoAnuncioC = "otros"
+)
+
+// TiposAnuncioC lists the Section C categories
+var TiposAnuncioC = []TipoAnuncioC{
+	TipoCConvocatoriaJunta, TipoCFusion, TipoCEscision, TipoCReduccionCapital, TipoCTransformacion,
+	TipoCDisolucion, TipoCCambioDomicilio, TipoCPerdidaCertificados, TipoCOtros,
+}
+
+// ParseTipoAnuncioC returns the category with the given name, e.g. "fusion"
+func ParseTipoAnuncioC(s string) (TipoAnuncioC, bool) {
+	for _, t := range TiposAnuncioC {
+		if string(t) == strings.ToLower(strings.TrimSpace(s)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// BormeCFilter selects Section C announcements
+type BormeCFilter func(b *BormeC) bool
+
+// TipoCFilter selects the Section C announcements of the given categories
+func TipoCFilter(tipos ...TipoAnuncioC) BormeCFilter {
+	return func(b *BormeC) bool {
+		for _, t := range tipos {
+			if b.Tipo == t {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package nombres
+
+// nombresPila is the bundled list of given names, in the form of Key, used
+// to tell the given names from the surnames. It holds the most frequent
+// given names in Spain, including the second part of compound names such
+// as "MARIA DEL CARMEN".
+var nombresPila = toSet(
+	// Male
+	"ADRIAN", "AGUSTIN", "ALBERT", "ALBERTO", "ALEJANDRO", "ALEX", "ALFONSO", "ALFREDO", "ALVARO",
+	"ANDRES", "ANGEL", "ANTONI", "ANTONIO", "ARTURO", "BENITO", "BERNARDO", "BORJA", "CARLES",
+	"CARLOS", "CESAR", "CRISTIAN", "DANIEL", "DAVID", "DIEGO", "DOMINGO", "EDUARDO", "EMILIO",
+	"ENRIQUE", "ERNESTO", "ESTEBAN", "EUGENIO", "FEDERICO", "FELIPE", "FELIX", "FERNANDO",
+	"FRANCESC", "FRANCISCO", "GABRIEL", "GERARDO", "GONZALO", "GREGORIO", "GUILLERMO", "HECTOR",
+	"HUGO", "IGNACIO", "IKER", "ISMAEL", "IVAN", "JAIME", "JAUME", "JAVIER", "JESUS", "JOAN",
+	"JOAQUIN", "JORDI", "JORGE", "JOSE", "JOSEP", "JUAN", "JULIAN", "JULIO", "LORENZO", "LUCAS",
+	"LUIS", "MANUEL", "MARC", "MARCOS", "MARIANO", "MARIO", "MARTIN", "MATEO", "MIGUEL", "NICOLAS",
+	"OSCAR", "PABLO", "PACO", "PASCUAL", "PATRICIO", "PEDRO", "PERE", "RAFAEL", "RAMON", "RAUL",
+	"RICARDO", "ROBERTO", "RODRIGO", "RUBEN", "SALVADOR", "SAMUEL", "SANTIAGO", "SERGIO",
+	"TOMAS", "VICENTE", "VICTOR", "XAVIER", "XABIER",
+
+	// Female
+	"ADRIANA", "ALBA", "ALICIA", "AMPARO", "ANA", "ANDREA", "ANGELA", "ANGELES", "ANNA", "ANTONIA",
+	"ASUNCION", "BEATRIZ", "BEGONA", "BLANCA", "CARLA", "CARMEN", "CAROLINA", "CATALINA",
+	"CELIA", "CLARA", "CONCEPCION", "CONSUELO", "CRISTINA", "DIANA", "DOLORES", "ELENA", "ELISA",
+	"ELVIRA", "EMILIA", "ENCARNACION", "ESPERANZA", "ESTHER", "EVA", "FRANCISCA", "GEMMA",
+	"GLORIA", "GUADALUPE", "INES", "INMACULADA", "IRENE", "ISABEL", "JOSEFA", "JUANA", "JULIA",
+	"LAURA", "LIDIA", "LOURDES", "LUCIA", "LUISA", "LUZ", "MAR", "MARGARITA", "MARIA", "MARINA",
+	"MARTA", "MERCEDES", "MONICA", "MONTSERRAT", "NATALIA", "NEREA", "NOELIA", "NURIA", "OLGA",
+	"PALOMA", "PATRICIA", "PAULA", "PILAR", "RAQUEL", "REMEDIOS", "ROCIO", "ROSA", "ROSARIO",
+	"SARA", "SILVIA", "SOFIA", "SONIA", "SUSANA", "TERESA", "VANESA", "VERONICA", "VICTORIA",
+	"VIRGINIA", "YOLANDA",
+)
+
+func toSet(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
@@ -0,0 +1,130 @@
+// Package nombres normalises the names of natural persons as published in
+// BORME, surnames first and upper case ("RAMA SANCHEZ JOSE PEDRO").
+package nombres
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// particulas are the words joined to the following word in compound
+// surnames and given names, e.g. "DE LA TORRE", "MARIA DEL CARMEN"
+var particulas = toSet("DE", "DEL", "LA", "LAS", "LOS", "Y", "I", "SAN", "SANTA", "DA", "DO", "DOS", "VAN", "VON")
+
+// keyReplacer folds the characters ignored when matching names
+var keyReplacer = strings.NewReplacer("Ñ", "N", "-", " ", ".", " ", ",", " ")
+
+// Key returns the match key of a person name: upper case, without accents
+// and punctuation, e.g. "Rama Sánchez, José-Pedro" -> "RAMA SANCHEZ JOSE PEDRO"
+func Key(name string) string {
+	s := keyReplacer.Replace(normalize.FoldAccents(strings.ToUpper(name)))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// unidad is a surname or given name, with its particles
+type unidad []string
+
+func (u unidad) String() string { return strings.Join(u, " ") }
+
+// conocido reports whether the unit is a given name of the bundled list
+func (u unidad) conocido() bool {
+	return nombresPila[Key(u[len(u)-1])]
+}
+
+// Parse normalises a person name and splits it into surnames and given
+// names. Names written "SURNAMES, GIVEN NAMES" are split at the comma;
+// otherwise the trailing words found in the bundled list of given names
+// are taken as the given names, keeping two surnames when possible. When
+// none is found the split is positional and NombreConocido is false.
+func Parse(name string) *models.NombrePersona {
+	name = strings.Join(strings.Fields(regex.TrimFinalDot(name)), " ")
+	if len(unidades(name)) == 0 {
+		return &models.NombrePersona{}
+	}
+	persona := &models.NombrePersona{Key: Key(name)}
+
+	var apellidos, nombre []unidad
+	if i := strings.Index(name, ","); i >= 0 {
+		apellidos, nombre = unidades(name[:i]), unidades(name[i+1:])
+		persona.NombreConocido = len(nombre) > 0 && nombre[len(nombre)-1].conocido()
+	} else {
+		apellidos = unidades(name)
+		maxNombre := len(apellidos) - min(2, len(apellidos)-1)
+		n := 0
+		for n < maxNombre && apellidos[len(apellidos)-1-n].conocido() {
+			n++
+		}
+		persona.NombreConocido = n > 0
+		if n == 0 {
+			n = maxNombre
+		}
+		apellidos, nombre = apellidos[:len(apellidos)-n], apellidos[len(apellidos)-n:]
+	}
+
+	if len(nombre) == 0 {
+		persona.Display = titulo(name)
+		return persona
+	}
+	persona.Nombre = unir(nombre)
+	if len(apellidos) > 0 {
+		persona.PrimerApellido = apellidos[0].String()
+		persona.SegundoApellido = unir(apellidos[1:])
+	}
+	persona.Display = titulo(strings.TrimSpace(persona.Nombre + " " + persona.PrimerApellido + " " + persona.SegundoApellido))
+	return persona
+}
+
+// unidades splits a name into units, joining the particles to the
+// following word. Hyphenated names are a single word.
+func unidades(s string) []unidad {
+	var result []unidad
+	var actual unidad
+	for _, w := range strings.Fields(strings.ToUpper(s)) {
+		actual = append(actual, w)
+		if !particulas[Key(w)] {
+			result = append(result, actual)
+			actual = nil
+		}
+	}
+	if len(actual) > 0 {
+		if len(result) > 0 {
+			result[len(result)-1] = append(result[len(result)-1], actual...)
+		} else {
+			result = append(result, actual)
+		}
+	}
+	return result
+}
+
+func unir(us []unidad) string {
+	parts := make([]string, len(us))
+	for i, u := range us {
+		parts[i] = u.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// titulo capitalises every word of a name but the particles, e.g.
+// "JOSE DE LA TORRE GARCIA-MORENO" -> "Jose de la Torre Garcia-Moreno"
+func titulo(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		if i > 0 && particulas[Key(w)] {
+			continue
+		}
+		runes := []rune(w)
+		upper := true
+		for j, r := range runes {
+			if upper {
+				runes[j] = unicode.ToUpper(r)
+			}
+			upper = r == '-' || r == '\''
+		}
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,106 @@
+// Package normalize cleans the text extracted from BORME PDFs and XML
+// documents: PDF escape sequences and font encodings, ligatures, soft
+// hyphens and Unicode normalisation, and builds accent-folded match keys.
+package normalize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SoftHyphen marks where a word may be split across lines
+const SoftHyphen = '\u00ad'
+
+// replacer expands ligatures and removes invisible characters
+var replacer = strings.NewReplacer(
+	"ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl",
+	"ﬅ", "st", "ﬆ", "st",
+	"\u00a0", " ", // no-break space
+	"\u200b", "", "\u200c", "", "\u200d", "", "\ufeff", "", // zero-width characters
+)
+
+// Text normalises extracted text to NFC, expanding ligatures, removing soft
+// hyphens inside the text and collapsing runs of spaces. Runs containing a
+// line break become a single "\n". A soft hyphen ending the text is kept so
+// that regex.JoinLines can rejoin the word split across lines.
+func Text(s string) string {
+	s = norm.NFC.String(replacer.Replace(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	space, newline, hyphen := false, false, false
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\r':
+			space, newline = true, true
+		case unicode.IsSpace(r) || unicode.IsControl(r):
+			space = true
+		case r == SoftHyphen:
+			hyphen = true
+		default:
+			if space && b.Len() > 0 {
+				if newline {
+					b.WriteByte('\n')
+				} else {
+					b.WriteByte(' ')
+				}
+			}
+			space, newline, hyphen = false, false, false
+			b.WriteRune(r)
+		}
+	}
+	if hyphen && b.Len() > 0 {
+		b.WriteRune(SoftHyphen)
+	}
+	return b.String()
+}
+
+// FoldAccents removes the accents and other diacritics ("Depósitos" ->
+// "Depositos", "Ça" -> "Ca"), keeping Ñ
+func FoldAccents(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev rune
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			if r == '\u0303' && (prev == 'n' || prev == 'N') {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return norm.NFC.String(b.String())
+}
+
+// Key returns the match key of a text: upper case, without accents and
+// with single spaces, e.g. " Cesión  global " -> "CESION GLOBAL"
+func Key(s string) string {
+	return strings.Join(strings.Fields(FoldAccents(strings.ToUpper(norm.NFC.String(s)))), " ")
+}
+
+// Capitalize upper-cases the first letter of s and lower-cases the rest,
+// e.g. "ÁREA DE NEGOCIO" -> "Área de negocio"
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + strings.ToLower(s[size:])
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
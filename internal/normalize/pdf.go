@@ -0,0 +1,143 @@
+package normalize
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Encoding maps the character codes of a PDF font to Unicode
+type Encoding struct {
+	Name        string
+	charmap     *charmap.Charmap
+	differences map[byte]rune
+}
+
+// Standard PDF encodings of simple fonts
+var (
+	WinAnsi  = &Encoding{Name: "WinAnsiEncoding", charmap: charmap.Windows1252}
+	MacRoman = &Encoding{Name: "MacRomanEncoding", charmap: charmap.Macintosh}
+)
+
+// EncodingByName returns the standard encoding with the given PDF name,
+// e.g. "WinAnsiEncoding", or nil
+func EncodingByName(name string) *Encoding {
+	for _, e := range []*Encoding{WinAnsi, MacRoman} {
+		if e.Name == strings.TrimPrefix(name, "/") {
+			return e
+		}
+	}
+	return nil
+}
+
+// WithDifferences returns a custom font encoding based on e, as declared
+// by the /Differences array of the font
+func (e *Encoding) WithDifferences(differences map[byte]rune) *Encoding {
+	custom := &Encoding{Name: e.Name, charmap: e.charmap, differences: make(map[byte]rune)}
+	for c, r := range e.differences {
+		custom.differences[c] = r
+	}
+	for c, r := range differences {
+		custom.differences[c] = r
+	}
+	return custom
+}
+
+// glyphs maps the glyph names used in /Differences arrays to their
+// characters: the Latin-1 letters and punctuation of Spanish text. Other
+// names are read as "uniXXXX" or not at all.
+var glyphs = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$',
+	"percent": '%', "ampersand": '&', "quotesingle": '\'', "parenleft": '(',
+	"parenright": ')', "asterisk": '*', "plus": '+', "comma": ',', "hyphen": '-',
+	"period": '.', "slash": '/', "colon": ':', "semicolon": ';', "less": '<',
+	"equal": '=', "greater": '>', "question": '?', "at": '@', "underscore": '_',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"Aacute": 'Á', "Eacute": 'É', "Iacute": 'Í', "Oacute": 'Ó', "Uacute": 'Ú',
+	"aacute": 'á', "eacute": 'é', "iacute": 'í', "oacute": 'ó', "uacute": 'ú',
+	"Agrave": 'À', "Egrave": 'È', "Igrave": 'Ì', "Ograve": 'Ò', "Ugrave": 'Ù',
+	"agrave": 'à', "egrave": 'è', "igrave": 'ì', "ograve": 'ò', "ugrave": 'ù',
+	"Adieresis": 'Ä', "Edieresis": 'Ë', "Idieresis": 'Ï', "Odieresis": 'Ö', "Udieresis": 'Ü',
+	"adieresis": 'ä', "edieresis": 'ë', "idieresis": 'ï', "odieresis": 'ö', "udieresis": 'ü',
+	"Ntilde": 'Ñ', "ntilde": 'ñ', "Ccedilla": 'Ç', "ccedilla": 'ç',
+	"ordfeminine": 'ª', "ordmasculine": 'º', "degree": '°',
+	"exclamdown": '¡', "questiondown": '¿', "guillemotleft": '«', "guillemotright": '»',
+	"quoteleft": '‘', "quoteright": '’', "quotedblleft": '“', "quotedblright": '”',
+	"endash": '–', "emdash": '—', "bullet": '•', "ellipsis": '…',
+	"periodcentered": '·', "section": '§', "Euro": '€',
+}
+
+// GlyphRune returns the character of a glyph name, e.g. "Oacute" -> 'Ó'
+// or "uni00D3" -> 'Ó'
+func GlyphRune(name string) (rune, bool) {
+	name = strings.TrimPrefix(name, "/")
+	if len(name) == 1 && (name[0] >= 'A' && name[0] <= 'Z' || name[0] >= 'a' && name[0] <= 'z') {
+		return rune(name[0]), true
+	}
+	if r, ok := glyphs[name]; ok {
+		return r, true
+	}
+	if hex, ok := strings.CutPrefix(name, "uni"); ok && len(hex) == 4 {
+		if code, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return rune(code), true
+		}
+	}
+	return 0, false
+}
+
+// DecodeByte returns the character of a code
+func (e *Encoding) DecodeByte(c byte) rune {
+	if r, ok := e.differences[c]; ok {
+		return r
+	}
+	if c < 0x80 {
+		return rune(c)
+	}
+	return e.charmap.DecodeByte(c)
+}
+
+// UnescapePDF resolves the escape sequences of a PDF string: "\(", "\)",
+// "\\", "\n"... and octal codes ("\363" -> "ó" in WinAnsi), decoded with
+// enc (WinAnsi if nil). Characters outside escapes are kept as they are.
+func UnescapePDF(s string, enc *Encoding) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	if enc == nil {
+		enc = WinAnsi
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'b', 'f':
+		case '\n':
+			// line continuation
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			code := 0
+			j := i
+			for ; j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7'; j++ {
+				code = code*8 + int(s[j]-'0')
+			}
+			i = j - 1
+			b.WriteRune(enc.DecodeByte(byte(code)))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package actos
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// actosConcursales lists the insolvency actos
+var actosConcursales = map[string]bool{
+	"Situación concursal":             true,
+	"Declaración de concurso":         true,
+	"Apertura de fase de liquidación": true,
+	"Resoluciones judiciales":         true,
+}
+
+// IsConcursal returns true for the insolvency actos
+func IsConcursal(name string) bool {
+	return actosConcursales[name]
+}
+
+// reCampoConcursal matches the labels of the fields of an insolvency acto,
+// e.g. "Juzgado: num. 1 de Madrid." or "Procedimiento concursal 123/2015."
+var reCampoConcursal = regexp.MustCompile(`(?i)\b(fecha\s+de\s+(?:la\s+)?resoluci[oó]n|procedimiento(?:\s+concursal)?|juzgado|juez|administrador(?:es)?\s+concursal(?:es)?|firmeza|resoluciones)\b\s*:?\s*`)
+
+var (
+	reProcedimiento = regexp.MustCompile(`\d+/\d{2,4}`)
+	reFechaNumerica = regexp.MustCompile(`(\d{1,2})/(\d{1,2})/(\d{4})`)
+)
+
+// fasesConcursales maps text found in the acto to the phase it reveals,
+// latest phase first
+var fasesConcursales = []struct {
+	texto string // lower-case, without accents
+	fase  models.FaseConcursal
+}{
+	{"conclusion", models.FaseConclusion},
+	{"liquidacion", models.FaseLiquidacion},
+	{"convenio", models.FaseConvenio},
+	{"concurso", models.FaseDeclaracion},
+}
+
+// ParseConcursal parses an insolvency acto such as "Procedimiento concursal
+// 123/2015. Fecha de resolución 12/03/2015. Concurso voluntario. Juzgado:
+// num. 1 de Madrid. Administrador concursal: GARCIA LOPEZ MARIA."
+func ParseConcursal(name, value string) *models.BormeActoConcursal {
+	acto := &models.BormeActoConcursal{
+		Name:  name,
+		Texto: value,
+		Fase:  faseConcursal(name + " " + value),
+	}
+
+	labels := reCampoConcursal.FindAllStringSubmatchIndex(value, -1)
+	for i, m := range labels {
+		end := len(value)
+		if i+1 < len(labels) {
+			end = labels[i+1][0]
+		}
+		campo := strings.ToLower(normalize.FoldAccents(value[m[2]:m[3]]))
+		valor := regex.TrimFinalDot(strings.Trim(strings.TrimSpace(value[m[1]:end]), ","))
+
+		switch {
+		case strings.HasPrefix(campo, "fecha"):
+			if f := reFechaNumerica.FindStringSubmatch(valor); f != nil {
+				acto.FechaResolucion = fechaNumerica(f)
+			}
+		case strings.HasPrefix(campo, "procedimiento"):
+			if acto.Procedimiento == "" {
+				acto.Procedimiento = reProcedimiento.FindString(valor)
+			}
+		case campo == "juzgado":
+			acto.Juzgado = primeraFrase(valor)
+		case campo == "juez":
+			acto.Juez = primeraFrase(valor)
+		case strings.HasPrefix(campo, "administrador"):
+			for _, nombre := range strings.Split(valor, ";") {
+				if nombre = regex.TrimFinalDot(strings.TrimSpace(nombre)); nombre != "" {
+					acto.Administradores = append(acto.Administradores, nombre)
+				}
+			}
+		}
+	}
+	return acto
+}
+
+// faseConcursal returns the latest phase mentioned in the text
+func faseConcursal(s string) models.FaseConcursal {
+	s = strings.ToLower(normalize.FoldAccents(s))
+	for _, f := range fasesConcursales {
+		if strings.Contains(s, f.texto) {
+			return f.fase
+		}
+	}
+	return ""
+}
+
+// reFinFrase matches the end of a sentence: a dot followed by a capital
+var reFinFrase = regexp.MustCompile(`\.\s+\p{Lu}`)
+
+// primeraFrase returns the first sentence of a field, e.g.
+// "num. 1 de Madrid. Concurso voluntario" -> "num. 1 de Madrid"
+func primeraFrase(s string) string {
+	if loc := reFinFrase.FindStringIndex(s); loc != nil {
+		return s[:loc[0]]
+	}
+	return s
+}
+
+// fechaNumerica returns the date of a reFechaNumerica match, or the zero
+// time if it is not a valid date
+func fechaNumerica(m []string) time.Time {
+	d, _ := strconv.Atoi(m[1])
+	mo, _ := strconv.Atoi(m[2])
+	y, _ := strconv.Atoi(m[3])
+	t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC)
+	if t.Day() != d || int(t.Month()) != mo {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,157 @@
+package actos
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// ActoCambioDenominacion is the acto recording a company rename
+const ActoCambioDenominacion = "Cambio de denominación social"
+
+// reNuevaDenominacion matches an optional label before the new name, e.g.
+// "Nueva denominación: ACME SL."
+var reNuevaDenominacion = regexp.MustCompile(`(?i)^(?:nueva\s+)?denominaci[oó]n(?:\s+social)?\s*:\s*`)
+
+// ParseDenominacion parses a Cambio de denominación social acto, whose value
+// is the new name, published in the anuncio of empresa under its previous name
+func ParseDenominacion(name, value, empresa string) *models.BormeActoDenominacion {
+	nueva := regex.TrimFinalDot(strings.TrimSpace(reNuevaDenominacion.ReplaceAllString(value, "")))
+	acto := &models.BormeActoDenominacion{
+		Name:     name,
+		Anterior: empresa,
+		Nueva:    nueva,
+	}
+	if empresa != "" {
+		acto.AnteriorNormalizada = regex.NormalizeEmpresa(empresa)
+	}
+	if nueva != "" {
+		acto.NuevaNormalizada = regex.NormalizeEmpresa(nueva)
+	}
+	return acto
+}
+
+// Denominaciones resolves the name history of companies from the
+// Cambio de denominación social actos of a set of bulletins, so that any of
+// the names of a company leads to the same entity. Names are compared in
+// their normalised form (see regex.NormalizeEmpresa), so they may be given
+// as published or normalised.
+type Denominaciones struct {
+	parent  map[string]string // union-find of normalised names
+	nombres map[string]string // normalised name -> name as published
+	cambios []cambio
+}
+
+// cambio is a CambioDenominacion with the normalised names
+type cambio struct {
+	models.CambioDenominacion
+	anterior, nueva string
+}
+
+// NewDenominaciones creates a name history from the given bulletins
+func NewDenominaciones(bormes ...*models.Borme) *Denominaciones {
+	d := &Denominaciones{
+		parent:  make(map[string]string),
+		nombres: make(map[string]string),
+	}
+	for _, b := range bormes {
+		d.Add(b)
+	}
+	return d
+}
+
+// Add adds the renames published in a bulletin
+func (d *Denominaciones) Add(b *models.Borme) {
+	for _, anuncio := range b.Anuncios {
+		for _, acto := range anuncio.Actos {
+			a, ok := acto.(*models.BormeActoDenominacion)
+			if !ok || a.AnteriorNormalizada == "" || a.NuevaNormalizada == "" {
+				continue
+			}
+			d.nombres[a.AnteriorNormalizada] = a.Anterior
+			d.nombres[a.NuevaNormalizada] = a.Nueva
+			d.union(a.AnteriorNormalizada, a.NuevaNormalizada)
+			d.cambios = append(d.cambios, cambio{
+				CambioDenominacion: models.CambioDenominacion{
+					Date:      b.Date,
+					AnuncioID: anuncio.ID,
+					Anterior:  a.Anterior,
+					Nueva:     a.Nueva,
+				},
+				anterior: a.AnteriorNormalizada,
+				nueva:    a.NuevaNormalizada,
+			})
+		}
+	}
+	sort.SliceStable(d.cambios, func(i, j int) bool {
+		if !d.cambios[i].Date.Equal(d.cambios[j].Date) {
+			return d.cambios[i].Date.Before(d.cambios[j].Date)
+		}
+		return d.cambios[i].AnuncioID < d.cambios[j].AnuncioID
+	})
+}
+
+// Historial returns the renames of the company with the given name,
+// oldest first
+func (d *Denominaciones) Historial(name string) []models.CambioDenominacion {
+	root := d.find(regex.NormalizeEmpresa(name))
+	var result []models.CambioDenominacion
+	for _, c := range d.cambios {
+		if d.find(c.anterior) == root {
+			result = append(result, c.CambioDenominacion)
+		}
+	}
+	return result
+}
+
+// Nombres returns all the names of the company with the given name, as
+// published and oldest first
+func (d *Denominaciones) Nombres(name string) []string {
+	historial := d.Historial(name)
+	if len(historial) == 0 {
+		if nombre, ok := d.nombres[regex.NormalizeEmpresa(name)]; ok {
+			return []string{nombre}
+		}
+		return nil
+	}
+	nombres := []string{historial[0].Anterior}
+	for _, c := range historial {
+		nombres = append(nombres, c.Nueva)
+	}
+	return nombres
+}
+
+// Actual returns the latest name, as published, of the company with the
+// given name, or "" if it was never renamed
+func (d *Denominaciones) Actual(name string) string {
+	historial := d.Historial(name)
+	if len(historial) == 0 {
+		return ""
+	}
+	return historial[len(historial)-1].Nueva
+}
+
+// Same reports whether two names belong to the same company
+func (d *Denominaciones) Same(a, b string) bool {
+	return d.find(regex.NormalizeEmpresa(a)) == d.find(regex.NormalizeEmpresa(b))
+}
+
+func (d *Denominaciones) find(name string) string {
+	for {
+		parent, ok := d.parent[name]
+		if !ok || parent == name {
+			return name
+		}
+		name = parent
+	}
+}
+
+func (d *Denominaciones) union(a, b string) {
+	ra, rb := d.find(a), d.find(b)
+	if ra != rb {
+		d.parent[ra] = rb
+	}
+}
@@ -0,0 +1,156 @@
+package actos
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser/cargos"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// ActoFeDeErratas is the acto correcting an anuncio published earlier
+const ActoFeDeErratas = "Fe de erratas"
+
+// References to the corrected anuncio, e.g. "publicado en el BORME núm. 101,
+// de fecha 28 de mayo de 2015, anuncio 57344" or "cve: BORME-A-2015-101-28"
+var (
+	reErrataNum     = regexp.MustCompile(`(?i)\bBORME\s+(?:n[uú]m(?:ero|\.)?\s*)?(\d+)`)
+	reErrataFecha   = regexp.MustCompile(`\p{L}+,? \d{1,2} de \p{L}+ de \d{4}`)
+	reErrataAnuncio = regexp.MustCompile(`(?i)\banuncio\s+(?:n[uú]m(?:ero|\.)?\s*)?(\d+)`)
+	reErrataCVE     = regexp.MustCompile(`BORME-[ABC]-\d{4}-\d+(?:-\d+)?`)
+	reDiceDebeDecir = regexp.MustCompile(`(?i)donde\s+dice\s*:?\s*"?(.+?)"?,?\s+debe\s+decir\s*:?\s*"?(.+?)"?\.?$`)
+)
+
+// ParseFeDeErratas parses a Fe de erratas acto, extracting the bulletin and
+// anuncio it corrects and, when stated, the erroneous and corrected text
+func ParseFeDeErratas(name, value string) *models.BormeActoFeDeErratas {
+	acto := &models.BormeActoFeDeErratas{
+		Name:  name,
+		Texto: value,
+	}
+	ref := &acto.Referencia
+
+	if m := reErrataCVE.FindString(value); m != "" {
+		if cve, err := models.ParseCVE(m); err == nil {
+			ref.CVE = cve
+			ref.Num = cve.NBO
+		}
+	}
+	if m := reErrataNum.FindStringSubmatch(value); m != nil {
+		ref.Num, _ = strconv.Atoi(m[1])
+	}
+	if m := reErrataFecha.FindString(value); m != "" {
+		if date, err := regex.ParseFecha(m); err == nil {
+			ref.Date = date
+		}
+	}
+	if m := reErrataAnuncio.FindStringSubmatch(value); m != nil {
+		ref.AnuncioID, _ = strconv.Atoi(m[1])
+	}
+	if m := reDiceDebeDecir.FindStringSubmatch(value); m != nil {
+		acto.Dice = strings.TrimSpace(m[1])
+		acto.DebeDecir = strings.TrimSpace(m[2])
+	}
+	return acto
+}
+
+// ApplyErratas links the Fe de erratas actos of the bulletins to the anuncios
+// they correct, found by year and anuncio number among the same bulletins.
+// Corrections stating the erroneous and the corrected text are applied to
+// the anuncio's company name, text actos and cargo holders, recomputing the
+// values derived from them. Applying the same bulletins again changes
+// nothing. Returns the errata whose anuncio was not found.
+func ApplyErratas(bormes ...*models.Borme) []*models.BormeActoFeDeErratas {
+	type clave struct{ year, id int }
+	anuncios := make(map[clave]*models.BormeAnuncio)
+	for _, b := range bormes {
+		for id, a := range b.Anuncios {
+			anuncios[clave{b.Date.Year(), id}] = a
+		}
+	}
+
+	var pendientes []*models.BormeActoFeDeErratas
+	for _, b := range bormes {
+		for _, anuncio := range b.AnunciosOrdenados() {
+			for _, acto := range anuncio.Actos {
+				errata, ok := acto.(*models.BormeActoFeDeErratas)
+				if !ok {
+					continue
+				}
+				year := errata.Referencia.Year()
+				if year == 0 {
+					year = b.Date.Year()
+				}
+				original, ok := anuncios[clave{year, errata.Referencia.AnuncioID}]
+				if !ok || errata.Referencia.AnuncioID == 0 {
+					pendientes = append(pendientes, errata)
+					continue
+				}
+
+				correccion := models.Correccion{
+					Date:      b.Date,
+					Num:       b.Num,
+					AnuncioID: anuncio.ID,
+					Dice:      errata.Dice,
+					DebeDecir: errata.DebeDecir,
+				}
+				if corregido(original, correccion) {
+					continue
+				}
+				correccion.Aplicada = corregir(original, errata.Dice, errata.DebeDecir)
+				original.Correcciones = append(original.Correcciones, correccion)
+			}
+		}
+	}
+	return pendientes
+}
+
+// corregido reports whether the correction was already recorded on the anuncio
+func corregido(a *models.BormeAnuncio, c models.Correccion) bool {
+	for _, prev := range a.Correcciones {
+		prev.Aplicada = false
+		if prev == c {
+			return true
+		}
+	}
+	return false
+}
+
+// corregir replaces dice with debeDecir in the company name, the text actos
+// and the cargo holders, and reports whether anything was replaced
+func corregir(a *models.BormeAnuncio, dice, debeDecir string) bool {
+	if dice == "" || debeDecir == "" {
+		return false
+	}
+	aplicada := false
+	if a.Empresa == dice {
+		a.Empresa = debeDecir
+		a.EmpresaNormalizada = regex.NormalizeEmpresa(debeDecir)
+		a.Sucursal, a.Liquidacion = regex.EmpresaFlags(debeDecir)
+		aplicada = true
+	}
+	for _, acto := range a.Actos {
+		switch acto := acto.(type) {
+		case *models.BormeActoTexto:
+			if acto.Value != nil && strings.Contains(*acto.Value, dice) {
+				value := strings.ReplaceAll(*acto.Value, dice, debeDecir)
+				acto.Value = &value
+				aplicada = true
+			}
+		case *models.BormeActoCargo:
+			for i := range acto.Value {
+				for j, h := range acto.Value[i].Holders {
+					if h.Name == dice {
+						holder := cargos.NewHolder(debeDecir)
+						holder.Representante = h.Representante
+						acto.Value[i].Holders[j] = holder
+						aplicada = true
+					}
+				}
+			}
+		}
+	}
+	return aplicada
+}
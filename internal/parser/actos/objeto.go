@@ -0,0 +1,136 @@
+package actos
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/cnae"
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/parser/cargos"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// Actos carrying the objeto social
+const (
+	ActoConstitucion           = "Constitución"
+	ActoCambioObjetoSocial     = "Cambio de objeto social"
+	ActoAmpliacionObjetoSocial = "Ampliacion del objeto social"
+)
+
+// IsObjetoSocial returns true for the actos changing the objeto social
+func IsObjetoSocial(name string) bool {
+	return name == ActoCambioObjetoSocial || name == ActoAmpliacionObjetoSocial
+}
+
+// reCampoConstitucion matches the labels of the fields of a Constitución, e.g.
+// "Comienzo de operaciones: 1.05.15. Objeto social: ... Domicilio: ..."
+var reCampoConstitucion = regexp.MustCompile(`(?i)\b(comienzo\s+de\s+operaciones|objeto\s+social|domicilio|capital|duraci[oó]n)\s*:\s*`)
+
+// campos splits a text into the values following each label matched by re,
+// keyed by the lower-case label without accents
+func campos(s string, re *regexp.Regexp) map[string]string {
+	result := make(map[string]string)
+	labels := re.FindAllStringSubmatchIndex(s, -1)
+	for i, m := range labels {
+		end := len(s)
+		if i+1 < len(labels) {
+			end = labels[i+1][0]
+		}
+		label := strings.Join(strings.Fields(strings.ToLower(normalize.FoldAccents(s[m[2]:m[3]]))), " ")
+		result[label] = regex.TrimFinalDot(strings.TrimSpace(s[m[1]:end]))
+	}
+	return result
+}
+
+// ParseConstitucion parses a Constitución acto such as "Comienzo de
+// operaciones: 1.05.15. Objeto social: ... Domicilio: C/ MAYOR 1
+// (BARCELONA). Capital: 3.000,00 Euros. Adm. Unico: PUIG FERRER JORDI."
+func ParseConstitucion(name, value string) *models.BormeActoConstitucion {
+	acto := &models.BormeActoConstitucion{
+		Name:  name,
+		Texto: value,
+	}
+
+	// Appointments published in the acto text follow the last field
+	value, acto.Cargos = splitCargos(value)
+
+	c := campos(value, reCampoConstitucion)
+	if m := reFechaCorta.FindStringSubmatch(c["comienzo de operaciones"]); m != nil {
+		acto.ComienzoOperaciones = fechaCorta(m)
+	}
+	if objeto := c["objeto social"]; objeto != "" {
+		acto.ObjetoSocial = cnae.NewObjetoSocial(objeto)
+	}
+	if domicilio := c["domicilio"]; domicilio != "" {
+		acto.Domicilio = ParseDomicilio(domicilio)
+	}
+	if capital := c["capital"]; capital != "" {
+		acto.Capital = ParseCapital(capital)
+	}
+	return acto
+}
+
+// splitCargos splits the cargos at the end of a text, starting at a
+// sentence with a known cargo label, e.g. "... 3.000,00 Euros. Adm. Unico: X."
+func splitCargos(s string) (string, []models.Cargo) {
+	for _, loc := range reFinFrase.FindAllStringIndex(s, -1) {
+		parsed := cargos.Parse(strings.TrimSpace(s[loc[0]+1:]))
+		if len(parsed) > 0 && parsed[0].Canonical != nil {
+			return s[:loc[0]+1], parsed
+		}
+	}
+	return s, nil
+}
+
+// reFechaCorta matches dates such as "1.05.15" or "01.05.2015"
+var reFechaCorta = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4}|\d{2})\b`)
+
+// fechaCorta returns the date of a reFechaCorta match; two-digit years are
+// taken as 20xx
+func fechaCorta(m []string) time.Time {
+	year := m[3]
+	if len(year) == 2 {
+		year = "20" + year
+	}
+	return fechaNumerica([]string{m[0], m[1], m[2], year})
+}
+
+var (
+	reCapital   = regexp.MustCompile(`^([\d.,]+)\s*(\p{L}*)`)
+	reDomicilio = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)$`)
+)
+
+// ParseCapital parses an amount of capital such as "3.000,00 Euros"
+func ParseCapital(s string) *models.Capital {
+	capital := &models.Capital{Texto: s}
+	if m := reCapital.FindStringSubmatch(s); m != nil {
+		capital.Importe, _ = regex.ParseImporte(m[1])
+		capital.Moneda = m[2]
+	}
+	return capital
+}
+
+// ParseDomicilio parses an address such as "C/ MAYOR 1 (BARCELONA)"
+func ParseDomicilio(s string) *models.Domicilio {
+	domicilio := &models.Domicilio{Direccion: s, Texto: s}
+	if m := reDomicilio.FindStringSubmatch(s); m != nil {
+		domicilio.Direccion = m[1]
+		domicilio.Municipio = strings.TrimSpace(m[2])
+	}
+	return domicilio
+}
+
+// reObjetoSocialLabel matches an optional label before the new objeto social
+var reObjetoSocialLabel = regexp.MustCompile(`(?i)^(?:nuevo\s+)?objeto(?:\s+social)?\s*:\s*`)
+
+// ParseObjetoSocial parses a Cambio or Ampliación de objeto social acto,
+// whose value is the new objeto social
+func ParseObjetoSocial(name, value string) *models.BormeActoObjetoSocial {
+	objeto := regex.TrimFinalDot(strings.TrimSpace(reObjetoSocialLabel.ReplaceAllString(value, "")))
+	return &models.BormeActoObjetoSocial{
+		Name:         name,
+		ObjetoSocial: cnae.NewObjetoSocial(objeto),
+	}
+}
@@ -0,0 +1,121 @@
+package actos
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// tiposReestructuracion maps the structural modification actos to their kind
+var tiposReestructuracion = map[string]models.TipoReestructuracion{
+	"Fusión":                           models.TipoFusion,
+	"Fusión por absorción":             models.TipoFusion,
+	"Escisión total":                   models.TipoEscisionTotal,
+	"Escisión parcial":                 models.TipoEscisionParcial,
+	"Cesión global de activo y pasivo": models.TipoCesionGlobal,
+}
+
+// IsReestructuracion returns true for the Fusión, Escisión and Cesión global
+// de activo y pasivo actos
+func IsReestructuracion(name string) bool {
+	_, ok := tiposReestructuracion[name]
+	return ok
+}
+
+// reRol matches the label introducing the companies of a role, e.g.
+// "Sociedades absorbidas:" or "Sociedad beneficiaria de la escisión:"
+var reRol = regexp.MustCompile(`(?i)\b(?:sociedad|entidad)(?:es)?\s+(absorbente|absorbida|escindida|beneficiaria|cedente|cesionaria)s?(?:\s+de\s+la\s+escisi[oó]n)?\s*:\s*`)
+
+// contraparte is the role of the anuncio's company when the acto lists the
+// companies of the other role
+var contraparte = map[models.RolReestructuracion]models.RolReestructuracion{
+	models.RolAbsorbente:   models.RolAbsorbida,
+	models.RolAbsorbida:    models.RolAbsorbente,
+	models.RolEscindida:    models.RolBeneficiaria,
+	models.RolBeneficiaria: models.RolEscindida,
+	models.RolCedente:      models.RolCesionaria,
+	models.RolCesionaria:   models.RolCedente,
+}
+
+// ParseReestructuracion parses a structural modification acto such as
+// "Sociedades absorbidas: ALFA SL. BETA SA." published in the anuncio of
+// empresa. The anuncio's company is added with the role opposite to the
+// listed companies, unless it is listed itself.
+func ParseReestructuracion(name, value, empresa string) *models.BormeActoReestructuracion {
+	acto := &models.BormeActoReestructuracion{
+		Name:          name,
+		Tipo:          tiposReestructuracion[name],
+		Texto:         value,
+		Participantes: make([]models.Participante, 0),
+	}
+
+	labels := reRol.FindAllStringSubmatchIndex(value, -1)
+	roles := make(map[models.RolReestructuracion]bool)
+	for i, m := range labels {
+		rol := models.RolReestructuracion(strings.ToLower(value[m[2]:m[3]]))
+		roles[rol] = true
+		end := len(value)
+		if i+1 < len(labels) {
+			end = labels[i+1][0]
+		}
+		for _, e := range SplitEmpresas(value[m[1]:end]) {
+			acto.Participantes = append(acto.Participantes, models.Participante{
+				Rol:                rol,
+				Empresa:            e,
+				EmpresaNormalizada: regex.NormalizeEmpresa(e),
+			})
+		}
+	}
+
+	if empresa == "" {
+		return acto
+	}
+	normalizada := regex.NormalizeEmpresa(empresa)
+	for i := range acto.Participantes {
+		if acto.Participantes[i].EmpresaNormalizada == normalizada {
+			acto.Participantes[i].Anuncio = true
+			return acto
+		}
+	}
+	// Only a single listed role tells which one the anuncio's company has
+	if len(roles) == 1 {
+		for rol := range roles {
+			acto.Participantes = append(acto.Participantes, models.Participante{
+				Rol:                contraparte[rol],
+				Empresa:            empresa,
+				EmpresaNormalizada: normalizada,
+				Anuncio:            true,
+			})
+		}
+	}
+	return acto
+}
+
+// SplitEmpresas splits a list of company names separated by ";" or by the
+// final dot of each name, e.g. "ALFA S.L. BETA SA." -> ["ALFA S.L.", "BETA SA"]
+func SplitEmpresas(s string) []string {
+	var empresas []string
+	add := func(e string) {
+		e = regex.TrimFinalDot(strings.Trim(strings.TrimSpace(e), ",;"))
+		if e != "" {
+			empresas = append(empresas, e)
+		}
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		// A dot followed by a space ends a name once what precedes it
+		// carries a legal form, so "S. L." does not split the name
+		current := ""
+		for _, piece := range strings.SplitAfter(strings.Join(strings.Fields(part), " "), ". ") {
+			current += piece
+			if regex.IsCompany(strings.TrimSpace(current)) && strings.HasSuffix(current, ". ") {
+				add(current)
+				current = ""
+			}
+		}
+		add(current)
+	}
+	return empresas
+}
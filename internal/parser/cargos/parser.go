@@ -0,0 +1,198 @@
+package cargos
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/nombres"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// Keywords lists the cargo abbreviations used in BORME Section A actos
+// (Nombramientos, Ceses/Dimisiones, Revocaciones, Reelecciones...), taken
+// from the models.CargoAbreviaturas catalogue. Names are matched
+// case-insensitively.
+var Keywords []string
+
+// keywordsLower holds Keywords lower-cased, longest first so that
+// "Liquidador M." wins over "Liquidador" at the same position
+var keywordsLower []string
+
+func init() {
+	Keywords = make([]string, 0, len(models.CargoAbreviaturas))
+	for k := range models.CargoAbreviaturas {
+		Keywords = append(Keywords, k)
+	}
+	sort.Strings(Keywords)
+
+	keywordsLower = make([]string, 0, len(Keywords))
+	for _, k := range Keywords {
+		keywordsLower = append(keywordsLower, strings.ToLower(k))
+	}
+	sort.SliceStable(keywordsLower, func(i, j int) bool {
+		return len(keywordsLower[i]) > len(keywordsLower[j])
+	})
+}
+
+// reUnknownCargo matches an unlisted cargo label at the start of the text,
+// e.g. "Mbro.Comite: ". Labels are mixed case, holder names are upper case.
+var reUnknownCargo = regexp.MustCompile(`^([^:;]{1,30}?)\s*:\s*`)
+
+// label is a cargo label found in the text
+type label struct {
+	name  string // as published, e.g. "Adm. Solid."
+	start int    // offset of the label
+	end   int    // offset right after the colon
+}
+
+// Parse parses the cargo assignments of an acto such as
+// "Adm. Solid.: RAMA SANCHEZ JOSE PEDRO;RAMA SANCHEZ JAVIER. Apoderado: ACME SL."
+// and returns the cargos in order of appearance. Holders of a cargo that
+// appears more than once are merged into its first entry.
+func Parse(s string) []models.Cargo {
+	s = strings.Join(strings.Fields(s), " ")
+	labels := findLabels(s)
+	if len(labels) == 0 {
+		return nil
+	}
+
+	result := make([]models.Cargo, 0, len(labels))
+	index := make(map[string]int)
+	var last *models.Holder // last holder added, for "Representante: X"
+	for i, l := range labels {
+		end := len(s)
+		if i+1 < len(labels) {
+			end = labels[i+1].start
+		}
+		holders := splitHolders(s[l.end:end])
+
+		// "Adm. Unico: ACME SL. Representante: PEREZ LOPEZ JUAN" names the
+		// person acting for the company, it is not a cargo of its own
+		if isRepresentante(l.name) && last != nil && last.IsEmpresa() && len(holders) > 0 {
+			last.Representante = strings.Join(models.Cargo{Holders: holders}.HolderNames(), ";")
+			continue
+		}
+
+		if idx, ok := index[l.name]; ok {
+			result[idx].Holders = append(result[idx].Holders, holders...)
+			last = lastHolder(result[idx].Holders)
+			continue
+		}
+		index[l.name] = len(result)
+		cargo := models.Cargo{Name: l.name, Holders: holders}
+		if info, ok := models.LookupCargo(l.name); ok {
+			cargo.Canonical = &info
+		}
+		result = append(result, cargo)
+		last = lastHolder(result[len(result)-1].Holders)
+	}
+
+	return result
+}
+
+// findLabels tokenizes s into cargo labels. A label is a known keyword
+// followed by a colon, found at the start of s or after a separator.
+func findLabels(s string) []label {
+	var labels []label
+
+	for i := 0; i < len(s); i++ {
+		if i > 0 && !isSeparator(s[i-1]) {
+			continue
+		}
+		if l, ok := matchKeyword(s, i); ok {
+			labels = append(labels, l)
+			i = l.end - 1
+		}
+	}
+
+	// Fall back to a generic label when the text starts with an unlisted cargo
+	if len(labels) == 0 || labels[0].start > 0 {
+		if m := reUnknownCargo.FindStringSubmatchIndex(s); m != nil {
+			name := s[m[2]:m[3]]
+			if hasLower(name) && (len(labels) == 0 || m[1] <= labels[0].start) {
+				labels = append([]label{{name: name, start: 0, end: m[1]}}, labels...)
+			}
+		}
+	}
+
+	return labels
+}
+
+// matchKeyword tries to match the longest keyword followed by a colon at offset i
+func matchKeyword(s string, i int) (label, bool) {
+	for _, k := range keywordsLower {
+		if i+len(k) > len(s) || !strings.EqualFold(s[i:i+len(k)], k) {
+			continue
+		}
+		j := i + len(k)
+		for j < len(s) && s[j] == ' ' {
+			j++
+		}
+		if j < len(s) && s[j] == ':' {
+			j++
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+			return label{name: s[i : i+len(k)], start: i, end: j}, true
+		}
+	}
+	return label{}, false
+}
+
+// splitHolders splits "NAME 1;NAME 2." into holders, classifying each one
+// as a natural person or a company and normalising the names of persons
+func splitHolders(s string) []models.Holder {
+	s = regex.TrimFinalDot(s)
+
+	holders := make([]models.Holder, 0)
+	for _, h := range strings.Split(s, ";") {
+		// A holder reduced to its final dot ("A;.;B") has no name
+		h = strings.TrimSpace(h)
+		if regex.TrimFinalDot(h) != "" {
+			holders = append(holders, NewHolder(h))
+		}
+	}
+	return holders
+}
+
+// NewHolder returns the holder with the given name, classified as a natural
+// person or a company, with the name normalised for persons
+func NewHolder(name string) models.Holder {
+	kind, forma := regex.ClassifyEntity(name)
+	holder := models.Holder{Name: name, Kind: kind, FormaJuridica: forma}
+	if kind == models.EntityPersona {
+		holder.Persona = nombres.Parse(name)
+	}
+	return holder
+}
+
+// isRepresentante returns true for labels introducing a company's representative
+func isRepresentante(name string) bool {
+	info, ok := models.LookupCargo(name)
+	return ok && info.ID == "representante"
+}
+
+// lastHolder returns a pointer to the last holder, or nil if there are none.
+// The pointer is only valid until the slice is appended to.
+func lastHolder(holders []models.Holder) *models.Holder {
+	if len(holders) == 0 {
+		return nil
+	}
+	return &holders[len(holders)-1]
+}
+
+func isSeparator(c byte) bool {
+	return c == ' ' || c == '.' || c == ';'
+}
+
+func hasLower(s string) bool {
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return true
+		}
+	}
+	return false
+}
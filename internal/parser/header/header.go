@@ -0,0 +1,351 @@
+package header
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// Running header and footer lines printed on every page, e.g.:
+//
+//	BOLETÍN OFICIAL DEL REGISTRO MERCANTIL
+//	Núm. 101 Jueves 28 de mayo de 2015 Pág. 6843
+//	...
+//	cve: BORME-A-2015-101-28
+//	Verificable en http://www.boe.es
+var (
+	reTitulo      = regexp.MustCompile(`^BOLET[IÍ]N OFICIAL DEL REGISTRO MERCANTIL$`)
+	reNumero      = regexp.MustCompile(`^Núm\.\s*(\d+)\s+(\p{L}+),?\s+(\d{1,2}\s+de\s+\p{L}+\s+de\s+\d{4})\s+Pág\.\s*(\d+)$`)
+	reCVE         = regexp.MustCompile(`^cve:\s*(\S+)$`)
+	reVerificable = regexp.MustCompile(`^Verificable en (\S+)$`)
+	reSeccion     = regexp.MustCompile(`^SECCIÓN (PRIMERA|SEGUNDA)$`)
+	reNombre      = regexp.MustCompile(`^BORME-([ABC])-(\d{4})-(\d+)-(\d+)$`)
+)
+
+// Subsection titles printed on the first page under "SECCIÓN PRIMERA"
+var subsecciones = map[string]models.Seccion{
+	"Actos inscritos": models.SeccionA,
+	"Otros actos publicados en el Registro Mercantil": models.SeccionB,
+}
+
+// diasSemana holds the weekday names, indexed by time.Weekday
+var diasSemana = [...]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+
+// ErrNoHeader is returned when the text has no bulletin header
+var ErrNoHeader = errors.New("bulletin header not found")
+
+// Fields checked by Validate
+const (
+	FieldNum         = "num"
+	FieldFecha       = "fecha"
+	FieldDiaSemana   = "dia_semana"
+	FieldSeccion     = "seccion"
+	FieldProvincia   = "provincia"
+	FieldCVE         = "cve"
+	FieldVerificable = "verificable"
+)
+
+// Sources of the values compared against the first-page header
+const (
+	SourcePage     = "page"
+	SourceCVE      = "cve"
+	SourceFilename = "filename"
+)
+
+// Pagina holds the running header and footer of a page
+type Pagina struct {
+	Num         int
+	Date        time.Time
+	DiaSemana   string // as published
+	Pagina      int    // "Pág." number, continuous across the year's bulletins
+	CVE         string
+	Verificable string // verification URL
+}
+
+// Header holds the bulletin metadata printed on the first page, along with
+// the running header and footer of every page
+type Header struct {
+	Num           int
+	Date          time.Time
+	DiaSemana     string
+	Titulo        string // "SECCIÓN PRIMERA"
+	Seccion       models.Seccion
+	Provincia     string // as published
+	ProvinciaCode int    // INE code, 0 if the province is unknown
+	CVE           string
+	Paginas       []Pagina
+}
+
+// MismatchError reports a header value that disagrees with the first page
+type MismatchError struct {
+	Field    string // one of the Field* constants
+	Source   string // one of the Source* constants
+	Page     int    // page number for SourcePage
+	Expected string // value from the first-page header
+	Found    string
+}
+
+func (e *MismatchError) Error() string {
+	where := e.Source
+	if e.Source == SourcePage {
+		where = fmt.Sprintf("page %d", e.Page)
+	}
+	return fmt.Sprintf("%s mismatch in %s: found %q, expected %q", e.Field, where, e.Found, e.Expected)
+}
+
+// IsRunningLine reports whether line is part of the running header or
+// footer repeated on every page
+func IsRunningLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return reTitulo.MatchString(line) || reNumero.MatchString(line) ||
+		reCVE.MatchString(line) || reVerificable.MatchString(line)
+}
+
+// Line is a body line of a bulletin and the page it is printed on
+type Line struct {
+	Text   string
+	Page   int // 1-based, 0 before the first running header
+	Offset int // byte offset of the line in the text
+}
+
+// Lines returns the body lines of a bulletin, without the running headers
+// and footers and the page breaks, so anuncios crossing a page continue on
+// the next line
+func Lines(text string) []Line {
+	lines := strings.Split(strings.ReplaceAll(text, "\f", "\n"), "\n")
+	body := make([]Line, 0, len(lines))
+	page, offset := 0, 0
+	for _, line := range lines {
+		if reNumero.MatchString(strings.TrimSpace(line)) {
+			page++
+		}
+		if !IsRunningLine(line) {
+			body = append(body, Line{Text: line, Page: page, Offset: offset})
+		}
+		offset += len(line) + 1
+	}
+	return body
+}
+
+// Strip removes the running headers and footers and the page breaks from
+// the text of a bulletin
+func Strip(text string) string {
+	lines := Lines(text)
+	body := make([]string, len(lines))
+	for i, line := range lines {
+		body[i] = line.Text
+	}
+	return strings.Join(body, "\n")
+}
+
+// Extract reads the bulletin header from the text of a bulletin. The
+// first page provides the header values; every page adds its running
+// header and footer to Paginas, which Validate checks.
+func Extract(text string) (*Header, error) {
+	h := &Header{}
+	page := Pagina{}
+	found := false
+	firstPage := true
+	awaitProvincia := false
+
+	addPage := func() {
+		h.Paginas = append(h.Paginas, page)
+		page = Pagina{}
+		firstPage = false
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\f", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if m := reNumero.FindStringSubmatch(line); m != nil {
+			// A running header before the previous footer closes a page without footer
+			if page.Num != 0 {
+				addPage()
+			}
+			page.Num, _ = strconv.Atoi(m[1])
+			page.DiaSemana = m[2]
+			page.Date, _ = regex.ParseFecha(m[2] + " " + m[3])
+			page.Pagina, _ = strconv.Atoi(m[4])
+			if !found {
+				found = true
+				h.Num = page.Num
+				h.Date = page.Date
+				h.DiaSemana = page.DiaSemana
+			}
+			continue
+		}
+
+		if m := reCVE.FindStringSubmatch(line); m != nil {
+			page.CVE = m[1]
+			if h.CVE == "" {
+				h.CVE = page.CVE
+			}
+			continue
+		}
+
+		if m := reVerificable.FindStringSubmatch(line); m != nil {
+			// The verification notice closes the page
+			page.Verificable = m[1]
+			addPage()
+			continue
+		}
+
+		if !firstPage || !found {
+			continue
+		}
+
+		switch {
+		case reSeccion.MatchString(line):
+			h.Titulo = line
+			if line == "SECCIÓN SEGUNDA" {
+				h.Seccion = models.SeccionC
+			}
+		case subsecciones[line] != "":
+			h.Seccion = subsecciones[line]
+			awaitProvincia = true
+		case awaitProvincia:
+			// The province follows the subsection title
+			h.Provincia = line
+			h.ProvinciaCode = provinciaCode(line)
+			awaitProvincia = false
+		}
+	}
+
+	if page != (Pagina{}) {
+		h.Paginas = append(h.Paginas, page)
+	}
+
+	if !found {
+		return nil, ErrNoHeader
+	}
+	return h, nil
+}
+
+// Validate checks that the running headers and footers of every page, the
+// CVE and the filename agree with the first-page header. Filenames may use
+// the CVE ("BORME-A-2015-101-28.pdf") or the date ("BORME-A-2015-05-28.pdf");
+// other names are not checked.
+func (h *Header) Validate(filename string) []*MismatchError {
+	var errs []*MismatchError
+	add := func(field, source string, page int, expected, found string) {
+		errs = append(errs, &MismatchError{Field: field, Source: source, Page: page, Expected: expected, Found: found})
+	}
+
+	if !h.Date.IsZero() {
+		dia := diasSemana[h.Date.Weekday()]
+		if !strings.EqualFold(normalize.FoldAccents(h.DiaSemana), normalize.FoldAccents(dia)) {
+			add(FieldDiaSemana, SourcePage, 1, dia, h.DiaSemana)
+		}
+	}
+
+	for i, page := range h.Paginas {
+		n := i + 1
+		if page.Num != 0 && page.Num != h.Num {
+			add(FieldNum, SourcePage, n, strconv.Itoa(h.Num), strconv.Itoa(page.Num))
+		}
+		if !page.Date.IsZero() && !page.Date.Equal(h.Date) {
+			add(FieldFecha, SourcePage, n, formatFecha(h.Date), formatFecha(page.Date))
+		}
+		if page.CVE != h.CVE {
+			add(FieldCVE, SourcePage, n, h.CVE, page.CVE)
+		}
+		if page.Verificable == "" {
+			add(FieldVerificable, SourcePage, n, "Verificable en http://www.boe.es", "")
+		}
+	}
+
+	if h.CVE != "" {
+		cve, err := models.ParseCVE(h.CVE)
+		if err != nil {
+			add(FieldCVE, SourceCVE, 0, "BORME-<seccion>-<year>-<num>-<provincia>", h.CVE)
+		} else {
+			errs = append(errs, h.validateCVE(cve)...)
+		}
+	}
+
+	if filename != "" {
+		if err := h.validateFilename(filename); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// validateCVE compares the parts of the CVE with the header values
+func (h *Header) validateCVE(cve models.CVE) []*MismatchError {
+	var errs []*MismatchError
+	add := func(field, expected, found string) {
+		errs = append(errs, &MismatchError{Field: field, Source: SourceCVE, Expected: expected, Found: found})
+	}
+
+	if h.Seccion != "" && cve.Seccion != h.Seccion {
+		add(FieldSeccion, string(h.Seccion), string(cve.Seccion))
+	}
+	if !h.Date.IsZero() && cve.Year != h.Date.Year() {
+		add(FieldFecha, strconv.Itoa(h.Date.Year()), strconv.Itoa(cve.Year))
+	}
+	// Section C CVEs carry the anuncio number instead of the bulletin number
+	if cve.Seccion == models.SeccionC {
+		return errs
+	}
+	if cve.NBO != h.Num {
+		add(FieldNum, strconv.Itoa(h.Num), strconv.Itoa(cve.NBO))
+	}
+	if h.ProvinciaCode != 0 && cve.Provincia != h.ProvinciaCode {
+		found := strconv.Itoa(cve.Provincia)
+		if p := models.ProvinciaFromINE(cve.Provincia); p != nil {
+			found = p.Name
+		}
+		add(FieldProvincia, h.Provincia, found)
+	}
+	return errs
+}
+
+// validateFilename compares a CVE or date based filename with the header
+func (h *Header) validateFilename(filename string) *MismatchError {
+	base := filepath.Base(filename)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	if base == h.CVE {
+		return nil
+	}
+	m := reNombre.FindStringSubmatch(base)
+	if m == nil {
+		return nil
+	}
+
+	// Date based name: BORME-{seccion}-{year}-{month}-{day}
+	if h.Seccion == "" || m[1] == string(h.Seccion) {
+		month, _ := strconv.Atoi(m[3])
+		day, _ := strconv.Atoi(m[4])
+		if m[2] == strconv.Itoa(h.Date.Year()) && month == int(h.Date.Month()) && day == h.Date.Day() {
+			return nil
+		}
+	}
+
+	return &MismatchError{Field: FieldCVE, Source: SourceFilename, Expected: h.CVE, Found: base}
+}
+
+// provinciaCode returns the INE code of a province name as published
+func provinciaCode(name string) int {
+	if p := models.ProvinciaFromName(name); p != nil {
+		return p.INE
+	}
+	return 0
+}
+
+// formatFecha formats a date for mismatch reports
+func formatFecha(t time.Time) string {
+	return t.Format("2006-01-02")
+}
@@ -1,41 +1,86 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/argami/gormeparser/internal/models"
 	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/argami/gormeparser/internal/parser/seccion_b"
 	"github.com/argami/gormeparser/internal/parser/seccion_c"
 )
 
+// Options configures the section parsers
+type Options struct {
+	// Strict makes parsing fail with a *models.DiagnosticsError when it
+	// produced warnings or errors
+	Strict bool
+	// Provenance records on each anuncio and acto the page and text it
+	// was parsed from (Section A)
+	Provenance bool
+	// Extractor gets the text of Section A PDFs, the built-in decoder if nil
+	Extractor pypdf2.TextExtractor
+	// Filter keeps only the anuncios it selects, all of them if nil (Section A)
+	Filter models.AnuncioFilter
+	// FilterC makes ParseWith return ErrFiltered for the announcements it
+	// does not select (Section C)
+	FilterC models.BormeCFilter
+}
+
+// ErrFiltered is returned with the announcements rejected by Options.FilterC
+var ErrFiltered = errors.New("announcement filtered out")
+
 // Parse parses a BORME file and returns the appropriate object based on section
 func Parse(filename string, seccion models.Seccion) (interface{}, error) {
+	return ParseWith(filename, seccion, Options{})
+}
+
+// ParseWith parses a BORME file like Parse, with the given options
+func ParseWith(filename string, seccion models.Seccion, opts Options) (interface{}, error) {
 	// Normalize section
 	seccion = models.Seccion(strings.ToUpper(string(seccion)))
 
 	switch seccion {
 	case models.SeccionA:
-		return ParseA(filename)
+		parseOpts := []pypdf2.Option{pypdf2.WithStrict(opts.Strict), pypdf2.WithProvenance(opts.Provenance)}
+		if opts.Extractor != nil {
+			parseOpts = append(parseOpts, pypdf2.WithExtractor(opts.Extractor))
+		}
+		borme, err := ParseA(filename, parseOpts...)
+		if err == nil && opts.Filter != nil {
+			borme.FilterAnuncios(opts.Filter)
+		}
+		return borme, err
 	case models.SeccionB:
-		return ParseA(filename) // Section B uses same parser as A
+		return ParseB(filename, seccionb.WithStrict(opts.Strict))
 	case models.SeccionC:
-		return ParseC(filename)
+		borme, err := ParseC(filename, seccionc.WithStrict(opts.Strict))
+		if err == nil && opts.FilterC != nil && !opts.FilterC(borme) {
+			return borme, ErrFiltered
+		}
+		return borme, err
 	default:
 		return nil, fmt.Errorf("sección no soportada: %s", seccion)
 	}
 }
 
 // ParseA parses a Section A PDF file
-func ParseA(filename string) (*models.Borme, error) {
-	parser := pypdf2.NewParser(filename)
+func ParseA(filename string, opts ...pypdf2.Option) (*models.Borme, error) {
+	parser := pypdf2.NewParser(filename, opts...)
+	return parser.Parse()
+}
+
+// ParseB parses a Section B file ("Otros actos publicados en el Registro Mercantil")
+func ParseB(filename string, opts ...seccionb.Option) (*models.BormeB, error) {
+	parser := seccionb.NewParser(filename, opts...)
 	return parser.Parse()
 }
 
 // ParseC parses a Section C XML/HTML file
-func ParseC(filename string) (*models.BormeC, error) {
-	parser := seccionc.NewParser(filename)
+func ParseC(filename string, opts ...seccionc.Option) (*models.BormeC, error) {
+	parser := seccionc.NewParser(filename, opts...)
 	return parser.Parse()
 }
 
@@ -0,0 +1,84 @@
+package pdftext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/normalize"
+)
+
+var (
+	// reFontResource matches a font resource of the page resources, e.g.
+	// "/F1 << /Type /Font /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"
+	reFontResource = regexp.MustCompile(`^(/[A-Za-z]+\d\w*)\s*<<(.*)>>$`)
+	reEncodingName = regexp.MustCompile(`/Encoding\s*/(\w+)`)
+	reEncodingDict = regexp.MustCompile(`/Encoding\s*<<(.*?)>>`)
+	reBaseEncoding = regexp.MustCompile(`/BaseEncoding\s*/(\w+)`)
+	reDifferences  = regexp.MustCompile(`/Differences\s*\[([^\]]*)\]`)
+)
+
+// IsFontResource reports whether a line of text is a font resource rather
+// than text set in the font
+func IsFontResource(line string) bool {
+	return reFontResource.MatchString(strings.TrimSpace(line))
+}
+
+// FontEncodings returns the encodings of the font resources found in the
+// text, by font name. A font with a named /Encoding gets that encoding and
+// one with an encoding dictionary gets its /BaseEncoding (WinAnsi if
+// missing) with its /Differences. Fonts without an /Encoding are left out.
+func FontEncodings(text string) map[string]*normalize.Encoding {
+	encodings := make(map[string]*normalize.Encoding)
+	for _, line := range strings.Split(text, "\n") {
+		m := reFontResource.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if enc := fontEncoding(m[2]); enc != nil {
+			encodings[m[1]] = enc
+		}
+	}
+	return encodings
+}
+
+// fontEncoding reads the /Encoding entry of a font dictionary
+func fontEncoding(dict string) *normalize.Encoding {
+	if m := reEncodingDict.FindStringSubmatch(dict); m != nil {
+		enc := normalize.WinAnsi
+		if b := reBaseEncoding.FindStringSubmatch(m[1]); b != nil {
+			if named := normalize.EncodingByName(b[1]); named != nil {
+				enc = named
+			}
+		}
+		if d := reDifferences.FindStringSubmatch(m[1]); d != nil {
+			enc = enc.WithDifferences(differences(d[1]))
+		}
+		return enc
+	}
+	if m := reEncodingName.FindStringSubmatch(dict); m != nil {
+		return normalize.EncodingByName(m[1])
+	}
+	return nil
+}
+
+// differences reads a /Differences array: each code is followed by the
+// glyph names of it and the codes after it, e.g. "[1 /ntilde /Ntilde]"
+func differences(array string) map[byte]rune {
+	diffs := make(map[byte]rune)
+	code := -1
+	for _, token := range strings.Fields(strings.ReplaceAll(array, "/", " /")) {
+		if n, err := strconv.Atoi(token); err == nil {
+			code = n
+			continue
+		}
+		if code < 0 || code > 255 {
+			continue
+		}
+		if r, ok := normalize.GlyphRune(token); ok {
+			diffs[byte(code)] = r
+		}
+		code++
+	}
+	return diffs
+}
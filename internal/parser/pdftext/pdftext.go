@@ -0,0 +1,38 @@
+// Package pdftext reads the text of BORME bulletins, shared by the Section
+// A and Section B parsers
+package pdftext
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadText reads the text content of a BORME file. Only text-based files
+// are supported; binary PDFs return an error.
+func ReadText(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	// Try to read as text first
+	scanner := bufio.NewScanner(file)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	text := strings.Join(lines, "\n")
+
+	// Check if it looks like a PDF
+	if strings.Contains(text, "%PDF") {
+		// It's a binary PDF, need proper library
+		// For now, return empty
+		return "", fmt.Errorf("binary PDF - requires proper PDF library")
+	}
+
+	return text, nil
+}
@@ -0,0 +1,77 @@
+package pypdf2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/parser/pdftext"
+)
+
+// TextExtractor gets the text of a BORME PDF in the marker format read by
+// the parser
+type TextExtractor interface {
+	Extract(filename string) (string, error)
+}
+
+// Extractor names accepted by ExtractorByName
+const (
+	ExtractorPDF  = "pdf"
+	ExtractorText = "text"
+)
+
+// ExtractorByName returns the extractor with the given name
+func ExtractorByName(name string) (TextExtractor, error) {
+	switch strings.ToLower(name) {
+	case "", ExtractorPDF:
+		return NewPDFTextExtractor(), nil
+	case ExtractorText:
+		return NewTextFileExtractor(""), nil
+	default:
+		return nil, fmt.Errorf("unknown text extractor %q (expected %s or %s)", name, ExtractorPDF, ExtractorText)
+	}
+}
+
+// PDFTextExtractor is the built-in PDF decoder
+type PDFTextExtractor struct{}
+
+// NewPDFTextExtractor creates a new extractor
+func NewPDFTextExtractor() *PDFTextExtractor {
+	return &PDFTextExtractor{}
+}
+
+// Extract extracts text from a PDF file
+func (e *PDFTextExtractor) Extract(filename string) (string, error) {
+	return pdftext.ReadText(filename)
+}
+
+// TextFileExtractor reads text extracted beforehand by an external tool and
+// stored alongside the PDF, e.g. BORME-A-2015-101-28.txt for
+// BORME-A-2015-101-28.pdf
+type TextFileExtractor struct {
+	Ext string // extension of the text files, ".txt" by default
+}
+
+// NewTextFileExtractor creates an extractor for text files with the given
+// extension
+func NewTextFileExtractor(ext string) *TextFileExtractor {
+	if ext == "" {
+		ext = ".txt"
+	}
+	return &TextFileExtractor{Ext: ext}
+}
+
+// Path returns the text file stored alongside the given PDF
+func (e *TextFileExtractor) Path(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + e.Ext
+}
+
+// Extract reads the text file stored alongside the given PDF
+func (e *TextFileExtractor) Extract(filename string) (string, error) {
+	data, err := os.ReadFile(e.Path(filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to read extracted text: %w", err)
+	}
+	return strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+}
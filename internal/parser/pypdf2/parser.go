@@ -1,70 +1,116 @@
 package pypdf2
 
 import (
-	"bufio"
 	"fmt"
-	"io"
-	"log"
-	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/parser/actos"
+	"github.com/argami/gormeparser/internal/parser/cargos"
+	"github.com/argami/gormeparser/internal/parser/header"
+	"github.com/argami/gormeparser/internal/parser/pdftext"
 	"github.com/argami/gormeparser/internal/regex"
 )
 
 // PyPDF2Parser parses Section A BORME PDFs
 type PyPDF2Parser struct {
-	filename string
-	data     *models.Borme
-	actos    []models.BormeActo
+	filename   string
+	strict     bool
+	provenance bool
+	extractor  TextExtractor
+	encodings  map[string]*normalize.Encoding // by font, from the font resources
+	text       string
+	data       *models.Borme
+	actos      []models.BormeActo
+}
+
+// Option configures a PyPDF2Parser
+type Option func(*PyPDF2Parser)
+
+// WithStrict makes Parse fail with a *models.DiagnosticsError when parsing
+// produced warnings or errors, instead of only recording them on the result
+func WithStrict(strict bool) Option {
+	return func(p *PyPDF2Parser) {
+		p.strict = strict
+	}
+}
+
+// WithExtractor sets the backend used to get the text of the PDF, the
+// built-in decoder by default
+func WithExtractor(extractor TextExtractor) Option {
+	return func(p *PyPDF2Parser) {
+		p.extractor = extractor
+	}
+}
+
+// WithProvenance makes Parse record on each anuncio and acto the page,
+// text offsets and raw text it was parsed from
+func WithProvenance(provenance bool) Option {
+	return func(p *PyPDF2Parser) {
+		p.provenance = provenance
+	}
 }
 
 // ParserState tracks the current parsing state
 type ParserState struct {
-	Cabecera   bool
-	Texto      bool
-	Fecha      bool
-	Numero     bool
-	Seccion    bool
-	Provincia  bool
-	CVE        bool
-	CurrentActo string
-	CurrentAnuncio *models.BormeAnuncio
+	Cabecera        bool
+	Texto           bool
+	Page            int    // page of the line being parsed
+	Font            string // font of the text, kept until the next font change
+	CurrentActo     string
+	ActoLines       []string // value of CurrentActo, possibly wrapped across lines and pages
+	ActoPage        int      // page where CurrentActo starts
+	ActoStart       int      // text offset where CurrentActo starts
+	ActoEnd         int      // text offset after the last line of CurrentActo
+	ActoEndPage     int      // page of the last line of CurrentActo
+	LastEnd         int      // text offset after the last line parsed
+	LastPage        int      // page of the last line parsed
+	CurrentAnuncio  *models.BormeAnuncio
+	PendingCabecera string // header text spanning several lines
+	CabeceraPage    int    // page where PendingCabecera starts
+	CabeceraStart   int    // text offset where PendingCabecera starts
 }
 
 // NewParser creates a new PyPDF2Parser
-func NewParser(filename string) *PyPDF2Parser {
-	return &PyPDF2Parser{
-		filename: filename,
+func NewParser(filename string, opts ...Option) *PyPDF2Parser {
+	p := &PyPDF2Parser{
+		filename:  filename,
+		extractor: NewPDFTextExtractor(),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
 // Parse parses a Section A PDF and returns a Borme object
 func (p *PyPDF2Parser) Parse() (*models.Borme, error) {
 	// Initialize Borme object
 	borme := &models.Borme{
-		Anuncios: make(map[int]*models.BormeAnuncio),
+		Seccion:    models.SeccionA,
+		Subseccion: models.SubseccionActosInscritos,
+		Anuncios:   make(map[int]*models.BormeAnuncio),
 	}
 	p.data = borme
 
 	// Initialize actos slice
 	p.actos = make([]models.BormeActo, 0)
 
-	// For now, we'll try to read the PDF content
-	// In production, we would use a proper PDF library
-	// This is a placeholder that shows the structure
-
-	// Try to read as text (some PDFs are text-based)
-	text, err := p.readPDFText()
+	text, err := p.extractor.Extract(p.filename)
 	if err != nil {
-		log.Printf("Warning: Could not read PDF as text: %v", err)
+		p.diagnose(nil, models.SeverityError, "", "could not extract text: %v", err)
 	}
 
 	if text != "" {
+		p.text = text
+		p.encodings = pdftext.FontEncodings(text)
+		p.parseHeader(text)
 		state := &ParserState{}
-		p.processText(text, state)
+		p.processText(header.Lines(text), state)
 	}
 
 	// Set announcement range
@@ -82,125 +128,188 @@ func (p *PyPDF2Parser) Parse() (*models.Borme, error) {
 		borme.SetAnunciosRango(minID, maxID)
 	}
 
+	if p.strict {
+		if diags := borme.Diagnostics.Filter(models.SeverityWarning); len(diags) > 0 {
+			return nil, &models.DiagnosticsError{Filename: p.filename, Diagnostics: diags}
+		}
+	}
+
 	return borme, nil
 }
 
-// readPDFText attempts to read PDF content
-func (p *PyPDF2Parser) readPDFText() (string, error) {
-	file, err := os.Open(p.filename)
-	if err != nil {
-		return "", err
+// diagnose records a problem on the result, at the page and anuncio being parsed
+func (p *PyPDF2Parser) diagnose(state *ParserState, severity models.Severity, snippet, format string, args ...interface{}) {
+	d := models.Diagnostic{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Snippet:  snippet,
 	}
-	defer file.Close()
-
-	// Try to read as text first
-	scanner := bufio.NewScanner(file)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	if state != nil {
+		d.Page = state.Page
+		if state.CurrentAnuncio != nil {
+			d.AnuncioID = state.CurrentAnuncio.ID
+		}
 	}
+	p.data.AddDiagnostic(d)
+}
 
-	text := strings.Join(lines, "\n")
+// parseHeader fills the bulletin metadata from the first-page header and
+// reports the pages whose running header or footer disagree with it
+func (p *PyPDF2Parser) parseHeader(text string) {
+	h, err := header.Extract(text)
+	if err != nil {
+		p.diagnose(nil, models.SeverityWarning, "", "%v", err)
+		return
+	}
 
-	// Check if it looks like a PDF
-	if strings.Contains(text, "%PDF") {
-		// It's a binary PDF, need proper library
-		// For now, return empty
-		return "", fmt.Errorf("binary PDF - requires proper PDF library")
+	p.data.Num = h.Num
+	p.data.Date = h.Date
+	if err := p.data.SetCVE(h.CVE); err != nil {
+		p.diagnose(nil, models.SeverityWarning, h.CVE, "%v", err)
+	}
+	if h.ProvinciaCode != 0 {
+		p.data.Provincia = models.ProvinciaFromINE(h.ProvinciaCode)
 	}
 
-	return text, nil
+	for _, m := range h.Validate(p.filename) {
+		p.diagnose(&ParserState{Page: m.Page}, models.SeverityWarning, m.Found, "%v", m)
+	}
 }
 
-// processText processes PDF text content
-func (p *PyPDF2Parser) processText(content string, state *ParserState) {
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+// processText processes the body lines of the PDF text
+func (p *PyPDF2Parser) processText(lines []header.Line, state *ParserState) {
+	for _, l := range lines {
+		line := strings.TrimSpace(l.Text)
+		state.Page = l.Page
 
-		if line == "" {
+		if line == "" || pdftext.IsFontResource(line) {
 			continue
 		}
+		lineEnd := l.Offset + len(l.Text)
+		font, fontText := splitFont(line)
+		if font != "" {
+			state.Font = font
+		}
+		// Escaped codes are decoded with the encoding of the current font,
+		// WinAnsi if its resource declares none
+		enc := p.encodings[state.Font]
 
 		// Check for markers
 		switch {
 		case strings.Contains(line, "Cabecera"):
+			p.flushActo(state)
+			p.closeAnuncio(state)
 			state.Cabecera = true
 			state.Texto = false
-			state.Fecha = false
-			state.Numero = false
-			state.Seccion = false
-			state.Provincia = false
-			state.CVE = false
 
 		case strings.Contains(line, "Texto"):
+			p.flushActo(state)
+			p.flushCabecera(state)
 			state.Texto = true
 			state.Cabecera = false
 
-		case strings.Contains(line, "Fecha"):
-			state.Fecha = true
-			state.Numero = false
-			state.Seccion = false
-			state.Provincia = false
-			state.CVE = false
-
-		case strings.HasPrefix(line, "/F1"):
+		case font == "/F1":
+			p.flushCabecera(state)
+			p.flushActo(state)
 			// Bold font - might be acto name
-			name := extractAfterFont(line, "/F1")
+			name := fontText
 			if name != "" && !strings.HasPrefix(name, "/") {
-				state.CurrentActo = regex.CleanPDFText(name)
+				state.CurrentActo = strings.TrimSuffix(regex.CleanPDFTextEncoding(name, enc), ".")
+				state.ActoPage = state.Page
+				state.ActoStart = l.Offset
+				state.ActoEnd = lineEnd
+				state.ActoEndPage = state.Page
 			}
 
-		case strings.HasPrefix(line, "/F2"):
-			// Normal font - acto value
-			value := extractAfterFont(line, "/F2")
+		case font == "/F2":
+			p.flushCabecera(state)
+			// Normal font - acto value, continued by the following lines
+			value := fontText
 			if value != "" && state.CurrentActo != "" {
-				p.parseActoValue(state.CurrentActo, regex.CleanPDFText(value))
-				state.CurrentActo = ""
+				state.ActoLines = append(state.ActoLines, regex.CleanPDFTextEncoding(value, enc))
+				state.ActoEnd = lineEnd
+				state.ActoEndPage = state.Page
 			}
 
-		case strings.HasPrefix(line, "Núm.") || strings.HasPrefix(line, "Num."):
-			// BORME number
-			if match := regex.REGEX_BORME_NUM.FindStringSubmatch(line); match != nil {
-				if num, err := strconv.Atoi(match[1]); err == nil {
-					p.data.Num = num
-				}
-			}
+		case state.Cabecera:
+			// Parse empresa header
+			p.parseCabecera(state, l, regex.CleanPDFTextEncoding(line, enc))
 
-		case strings.HasPrefix(line, "cve:") || strings.Contains(line, "CVE"):
-			// CVE code
-			if match := regex.REGEX_BORME_CVE.FindStringSubmatch(line); match != nil {
-				p.data.SetCVE(match[1])
-			}
+		case state.Texto && state.CurrentActo != "":
+			// Continuation of the acto text
+			state.ActoLines = append(state.ActoLines, regex.CleanPDFTextEncoding(line, enc))
+			state.ActoEnd = lineEnd
+			state.ActoEndPage = state.Page
 
-		case state.Fecha && !state.Numero:
-			// Parse date
-			if t, err := regex.ParseFecha(line); err == nil && !t.IsZero() {
-				p.data.Date = t
-			}
+		case state.Texto:
+			p.diagnose(state, models.SeverityWarning, line, "text outside of any acto")
+		}
 
-		case state.Provincia && !state.CVE:
-			// Parse province
-			if prov := models.FromTitle(line); prov != nil {
-				p.data.Provincia = prov
-			}
+		state.LastEnd = lineEnd
+		state.LastPage = state.Page
+	}
 
-		case state.CVE:
-			// Parse CVE
-			if match := regex.REGEX_BORME_CVE.FindStringSubmatch(line); match != nil {
-				p.data.SetCVE(match[1])
-			}
+	p.flushActo(state)
+	p.flushCabecera(state)
+	p.closeAnuncio(state)
+}
 
-		case state.Cabecera:
-			// Parse empresa header
-			p.parseCabecera(line)
+// source returns the provenance of the text between two offsets, or nil
+// when it is not being recorded
+func (p *PyPDF2Parser) source(page, endPage, start, end int) *models.Provenance {
+	if !p.provenance {
+		return nil
+	}
+	prov := &models.Provenance{
+		Page:  page,
+		Start: start,
+		End:   end,
+		Raw:   p.text[start:end],
+	}
+	if endPage != page {
+		prov.EndPage = endPage
+	}
+	return prov
+}
 
-		case state.Texto && state.CurrentActo != "":
-			// Parse acto text
-			p.parseActoValue(state.CurrentActo, regex.CleanPDFText(line))
+// closeAnuncio ends the provenance of the current anuncio at the last line parsed
+func (p *PyPDF2Parser) closeAnuncio(state *ParserState) {
+	anuncio := state.CurrentAnuncio
+	// An empty range means the anuncio is still open
+	if anuncio == nil || anuncio.Provenance == nil || anuncio.Provenance.End != anuncio.Provenance.Start {
+		return
+	}
+	prov := anuncio.Provenance
+	anuncio.Provenance = p.source(prov.Page, state.LastPage, prov.Start, state.LastEnd)
+}
+
+// flushActo creates the current acto once its whole value has been read
+func (p *PyPDF2Parser) flushActo(state *ParserState) {
+	if state.CurrentActo != "" && len(state.ActoLines) > 0 {
+		// Report problems of the acto on the page where it starts
+		page := state.Page
+		state.Page = state.ActoPage
+		acto := p.parseActoValue(state, state.CurrentActo, regex.JoinLines(state.ActoLines))
+		state.Page = page
+
+		if acto != nil && p.provenance {
+			acto.SetProvenance(p.source(state.ActoPage, state.ActoEndPage, state.ActoStart, state.ActoEnd))
 		}
 	}
+	state.CurrentActo = ""
+	state.ActoLines = nil
+}
+
+// reFont matches a font resource at the start of a line, e.g. "/F1" or "/C2_0"
+var reFont = regexp.MustCompile(`^(/[A-Za-z]+\d\w*)(?:\s|$)`)
+
+// splitFont splits a line into its leading font resource, if any, and its text
+func splitFont(line string) (font, text string) {
+	m := reFont.FindStringSubmatch(line)
+	if m == nil {
+		return "", line
+	}
+	return m[1], extractAfterFont(line, m[1])
 }
 
 // extractAfterFont extracts text after font marker
@@ -217,68 +326,171 @@ func extractAfterFont(line, font string) string {
 	return text
 }
 
-// parseCabecera parses the company header
-func (p *PyPDF2Parser) parseCabecera(line string) {
-	// Check if this line contains empresa info
-	if strings.Contains(line, " - ") {
-		id, name, registro := regex.ParseEmpresa(line)
-		if id != "" {
-			// Create new anuncio
-			anuncio := &models.BormeAnuncio{
-				ID:      len(p.data.Anuncios) + 1,
-				Empresa: name,
-			}
-			if registro != nil {
-				anuncio.Registro = registro["registro"]
-			}
-			p.data.Anuncios[anuncio.ID] = anuncio
-		}
+// parseCabecera collects the company header, which may span several lines
+// when the company name is long
+func (p *PyPDF2Parser) parseCabecera(state *ParserState, l header.Line, line string) {
+	if regex.REGEX_CABECERA.MatchString(line) {
+		p.flushCabecera(state)
+		state.PendingCabecera = line
+		state.CabeceraPage = l.Page
+		state.CabeceraStart = l.Offset
+	} else if state.PendingCabecera != "" {
+		state.PendingCabecera = regex.JoinLines([]string{state.PendingCabecera, line})
+	} else {
+		p.diagnose(state, models.SeverityWarning, line, "unrecognised anuncio header")
+		return
+	}
+
+	// A header is complete once it ends with its final dot or "(R.M. X)"
+	if regex.CabeceraCompleta(state.PendingCabecera) {
+		p.flushCabecera(state)
+	}
+}
+
+// flushCabecera creates the anuncio for the pending company header
+func (p *PyPDF2Parser) flushCabecera(state *ParserState) {
+	if state.PendingCabecera == "" {
+		return
+	}
+	raw := state.PendingCabecera
+	cabecera := regex.ParseCabecera(raw)
+	state.PendingCabecera = ""
+	if cabecera == nil {
+		p.diagnose(state, models.SeverityWarning, raw, "unrecognised anuncio header")
+		return
+	}
+
+	// Keep the anuncio number published in the bulletin
+	id, err := strconv.Atoi(cabecera.ID)
+	if err != nil {
+		p.diagnose(state, models.SeverityWarning, raw, "invalid anuncio number %q: %v", cabecera.ID, err)
+		return
+	}
+
+	// Create new anuncio
+	anuncio := &models.BormeAnuncio{
+		ID:                 id,
+		Empresa:            cabecera.Name,
+		EmpresaNormalizada: cabecera.Normalizada,
+		Registro:           cabecera.Registro,
+		Sucursal:           cabecera.Sucursal,
+		Liquidacion:        cabecera.Liquidacion,
 	}
+	// Completed by closeAnuncio once the whole anuncio has been read
+	anuncio.Provenance = p.source(state.CabeceraPage, state.CabeceraPage, state.CabeceraStart, state.CabeceraStart)
+	state.CurrentAnuncio = anuncio
+
+	// A repeated number is a parsing or publishing error: keep the first
+	// anuncio and set the duplicate, with its own actos, aside in Duplicados
+	if prev, ok := p.data.Anuncios[id]; ok {
+		p.diagnose(state, models.SeverityWarning, raw, "duplicate anuncio %d, already seen as %s", id, prev.Empresa)
+		p.data.Duplicados = append(p.data.Duplicados, anuncio)
+		return
+	}
+	p.data.Anuncios[anuncio.ID] = anuncio
 }
 
-// parseActoValue parses the value of an acto
-func (p *PyPDF2Parser) parseActoValue(name, value string) {
+// parseActoValue parses the value of an acto and adds it to the current anuncio
+func (p *PyPDF2Parser) parseActoValue(state *ParserState, name, value string) models.BormeActo {
 	// Clean the value
 	value = strings.TrimSpace(value)
 	if value == "" {
-		return
+		return nil
+	}
+
+	if !regex.IsActoConocido(name) {
+		p.diagnose(state, models.SeverityWarning, name, "unrecognised acto")
+	}
+
+	var empresa string
+	if state.CurrentAnuncio != nil {
+		empresa = state.CurrentAnuncio.Empresa
 	}
 
 	// Create acto based on type
-	if regex.IsActoCargo(name) {
-		// Parse cargos
-		cargos := regex.ParseCargos(value)
+	var acto models.BormeActo
+	if name == actos.ActoCambioDenominacion {
+		acto = actos.ParseDenominacion(name, value, empresa)
+
+	} else if name == actos.ActoFeDeErratas {
+		errata := actos.ParseFeDeErratas(name, value)
+		if errata.Referencia.AnuncioID == 0 {
+			p.diagnose(state, models.SeverityInfo, value, "no anuncio referenced in %s", name)
+		}
+		acto = errata
+
+	} else if name == actos.ActoConstitucion {
+		acto = actos.ParseConstitucion(name, value)
+
+	} else if actos.IsObjetoSocial(name) {
+		acto = actos.ParseObjetoSocial(name, value)
 
-		acto := &models.BormeActoCargo{
+	} else if actos.IsConcursal(name) {
+		acto = actos.ParseConcursal(name, value)
+
+	} else if actos.IsReestructuracion(name) {
+		reestructuracion := actos.ParseReestructuracion(name, value, empresa)
+		if len(reestructuracion.Participantes) == 0 {
+			p.diagnose(state, models.SeverityInfo, value, "no companies found in %s", name)
+		}
+		acto = reestructuracion
+
+	} else if regex.IsActoCargo(name) {
+		// Parse cargos
+		parsed := cargos.Parse(value)
+		if len(parsed) == 0 {
+			p.diagnose(state, models.SeverityWarning, value, "no cargos found in %s", name)
+		}
+		for _, c := range parsed {
+			if c.Canonical == nil {
+				p.diagnose(state, models.SeverityInfo, c.Name, "unlisted cargo")
+			}
+		}
+		acto = &models.BormeActoCargo{
 			Name:  name,
-			Value: cargos,
+			Value: parsed,
 		}
-		p.actos = append(p.actos, acto)
 
 	} else if regex.IsActoBold(name) {
 		// Bold acto
-		acto := &models.BormeActoTexto{
+		acto = &models.BormeActoTexto{
 			Name:  name,
 			Value: &value,
 		}
-		p.actos = append(p.actos, acto)
 
 	} else if regex.IsActoColon(name) {
 		// Acto with colon argument
-		acto := &models.BormeActoTexto{
+		acto = &models.BormeActoTexto{
 			Name:  name,
 			Value: &value,
 		}
-		p.actos = append(p.actos, acto)
 
 	} else {
 		// Regular acto
-		acto := &models.BormeActoTexto{
+		acto = &models.BormeActoTexto{
 			Name:  name,
 			Value: &value,
 		}
-		p.actos = append(p.actos, acto)
 	}
+
+	p.addActo(state, acto)
+	return acto
+}
+
+// addActo attaches an acto to the current anuncio
+func (p *PyPDF2Parser) addActo(state *ParserState, acto models.BormeActo) {
+	if state.CurrentAnuncio != nil {
+		// The appointments following a Constitución form the initial board
+		if nombramientos, ok := acto.(*models.BormeActoCargo); ok && nombramientos.Name == "Nombramientos" {
+			if constitucion := state.CurrentAnuncio.Constitucion(); constitucion != nil {
+				constitucion.Cargos = append(constitucion.Cargos, nombramientos.Value...)
+			}
+		}
+		state.CurrentAnuncio.Actos = append(state.CurrentAnuncio.Actos, acto)
+		return
+	}
+	p.diagnose(state, models.SeverityWarning, acto.GetName(), "acto outside of any anuncio")
+	p.actos = append(p.actos, acto)
 }
 
 // ParseFilename extracts date and section from filename
@@ -314,41 +526,3 @@ func ParseFilename(filename string) (time.Time, models.Seccion, int, error) {
 
 	return date, seccion, nbo, nil
 }
-
-// PDFTextExtractor extracts text from PDF files
-type PDFTextExtractor struct{}
-
-// NewPDFTextExtractor creates a new extractor
-func NewPDFTextExtractor() *PDFTextExtractor {
-	return &PDFTextExtractor{}
-}
-
-// Extract extracts text from a PDF file
-func (e *PDFTextExtractor) Extract(filename string) (string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	// Read first bytes to check if it's a text PDF
-	header := make([]byte, 100)
-	_, err = file.Read(header)
-	if err != nil && err != io.EOF {
-		return "", err
-	}
-
-	// Check if it's a text-based PDF
-	if string(header[:4]) == "%PDF" {
-		// Try to read as text
-		file.Seek(0, 0)
-		scanner := bufio.NewScanner(file)
-		var lines []string
-		for scanner.Scan() {
-			lines = append(lines, scanner.Text())
-		}
-		return strings.Join(lines, "\n"), nil
-	}
-
-	return "", fmt.Errorf("binary PDF - requires proper PDF parsing library")
-}
@@ -0,0 +1,219 @@
+package seccionb
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/parser/header"
+	"github.com/argami/gormeparser/internal/parser/pdftext"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+// subsecciones maps the Section B subsection headings, lower-cased and
+// without accents, to the kind of entries they contain
+var subsecciones = map[string]models.TipoEntradaB{
+	"reducciones de capital":                                   models.TipoEntradaBReduccionCapital,
+	"reduccion de capital":                                     models.TipoEntradaBReduccionCapital,
+	"ampliaciones de capital":                                  models.TipoEntradaBAmpliacionCapital,
+	"ampliacion de capital":                                    models.TipoEntradaBAmpliacionCapital,
+	"sociedades absorbidas":                                    models.TipoEntradaBSociedadAbsorbida,
+	"depositos de cuentas":                                     models.TipoEntradaBDepositoCuentas,
+	"depositos de cuentas anuales":                             models.TipoEntradaBDepositoCuentas,
+	"cierres provisionales de hoja registral":                  models.TipoEntradaBCierreHoja,
+	"cierre provisional hoja registral por revocacion del nif": models.TipoEntradaBCierreHoja,
+	"cierre provisional de la hoja registral por baja en el indice de entidades juridicas": models.TipoEntradaBCierreHoja,
+	"cancelaciones de oficio":             models.TipoEntradaBCancelacionOficio,
+	"cancelaciones de oficio de asientos": models.TipoEntradaBCancelacionOficio,
+	"otros":                               models.TipoEntradaBOtros,
+}
+
+var (
+	reFontMarker      = regexp.MustCompile(`^/F\d+\s+`)
+	reEjercicio       = regexp.MustCompile(`(?i)ejercicio\s*:?\s*(\d{4})`)
+	reEjercicioNombre = regexp.MustCompile(`\s*\((\d{4})\)$`)
+	reAbsorbente      = regexp.MustCompile(`(?i)sociedad(?:es)?\s+absorbente\s*:\s*(.+)$`)
+	reImporte         = regexp.MustCompile(`(?i)importe[^:]*:\s*([\d.,]+)`)
+	reResultante      = regexp.MustCompile(`(?i)resultante[^:]*:\s*([\d.,]+)`)
+)
+
+// BormeBParser parses Section B BORME bulletins
+type BormeBParser struct {
+	filename string
+	strict   bool
+}
+
+// Option configures a BormeBParser
+type Option func(*BormeBParser)
+
+// WithStrict makes Parse fail with a *models.DiagnosticsError when parsing
+// produced warnings or errors
+func WithStrict(strict bool) Option {
+	return func(p *BormeBParser) {
+		p.strict = strict
+	}
+}
+
+// NewParser creates a new Section B parser
+func NewParser(filename string, opts ...Option) *BormeBParser {
+	p := &BormeBParser{
+		filename: filename,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse parses a Section B file and returns a BormeB object
+func (p *BormeBParser) Parse() (*models.BormeB, error) {
+	text, err := pdftext.ReadText(p.filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	borme := models.NewBormeB()
+	filename := p.filename
+	borme.Filename = &filename
+
+	p.parseHeader(borme, text)
+	p.processText(borme, text)
+
+	if p.strict {
+		if diags := borme.Diagnostics.Filter(models.SeverityWarning); len(diags) > 0 {
+			return nil, &models.DiagnosticsError{Filename: p.filename, Diagnostics: diags}
+		}
+	}
+
+	return borme, nil
+}
+
+// parseHeader fills the bulletin metadata from the first-page header and
+// reports the pages whose running header or footer disagree with it
+func (p *BormeBParser) parseHeader(borme *models.BormeB, text string) {
+	h, err := header.Extract(text)
+	if err != nil {
+		borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: err.Error()})
+		return
+	}
+
+	borme.Num = h.Num
+	borme.Date = h.Date
+	if borme.CVE, err = models.ParseCVE(h.CVE); err != nil {
+		borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: err.Error(), Snippet: h.CVE})
+	}
+	if h.ProvinciaCode != 0 {
+		borme.Provincia = models.ProvinciaFromINE(h.ProvinciaCode)
+	}
+
+	for _, m := range h.Validate(p.filename) {
+		borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Page: m.Page, Message: m.Error(), Snippet: m.Found})
+	}
+}
+
+// processText splits the text into subsections and entries
+func (p *BormeBParser) processText(borme *models.BormeB, content string) {
+	var current *models.BormeBEntrada
+	var body []string
+	heading := ""
+	tipo := models.TipoEntradaBOtros
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Texto = regex.JoinLines(body)
+		fillEntrada(current, body)
+		borme.Entradas = append(borme.Entradas, *current)
+		current = nil
+		body = nil
+	}
+
+	// Escaped codes are decoded with the encoding of the current font
+	encodings := pdftext.FontEncodings(content)
+	font := ""
+
+	for _, line := range strings.Split(header.Strip(content), "\n") {
+		line = strings.TrimSpace(line)
+		if pdftext.IsFontResource(line) {
+			continue
+		}
+		if m := reFontMarker.FindString(line); m != "" {
+			font = strings.TrimSpace(m)
+			line = line[len(m):]
+		}
+		line = regex.CleanPDFTextEncoding(line, encodings[font])
+		if line == "" || line == "Cabecera" || line == "Texto" {
+			continue
+		}
+
+		if t, ok := matchSubseccion(line); ok {
+			flush()
+			heading = strings.TrimSuffix(line, ".")
+			tipo = t
+			continue
+		}
+
+		if cabecera := regex.ParseCabecera(line); cabecera != nil {
+			id, err := strconv.Atoi(cabecera.ID)
+			if err == nil {
+				flush()
+				current = &models.BormeBEntrada{
+					ID:                 id,
+					Empresa:            cabecera.Name,
+					EmpresaNormalizada: cabecera.Normalizada,
+					Registro:           cabecera.Registro,
+					Tipo:               tipo,
+					Subseccion:         heading,
+				}
+				continue
+			}
+		}
+
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+
+	flush()
+}
+
+// matchSubseccion returns the entry kind if line is a subsection heading
+func matchSubseccion(line string) (models.TipoEntradaB, bool) {
+	key := strings.ToLower(normalize.FoldAccents(strings.TrimSuffix(line, ".")))
+	tipo, ok := subsecciones[key]
+	return tipo, ok
+}
+
+// fillEntrada sets the typed fields of an entry from its body lines
+func fillEntrada(e *models.BormeBEntrada, body []string) {
+	switch e.Tipo {
+	case models.TipoEntradaBDepositoCuentas:
+		if m := reEjercicioNombre.FindStringSubmatch(e.Empresa); m != nil {
+			e.Ejercicio, _ = strconv.Atoi(m[1])
+			e.Empresa = strings.TrimSpace(reEjercicioNombre.ReplaceAllString(e.Empresa, ""))
+			e.EmpresaNormalizada = regex.NormalizeEmpresa(e.Empresa)
+		} else if m := reEjercicio.FindStringSubmatch(e.Texto); m != nil {
+			e.Ejercicio, _ = strconv.Atoi(m[1])
+		}
+
+	case models.TipoEntradaBSociedadAbsorbida:
+		for _, line := range body {
+			if m := reAbsorbente.FindStringSubmatch(line); m != nil {
+				e.Absorbente = regex.TrimFinalDot(m[1])
+				break
+			}
+		}
+
+	case models.TipoEntradaBReduccionCapital, models.TipoEntradaBAmpliacionCapital:
+		if m := reImporte.FindStringSubmatch(e.Texto); m != nil {
+			e.Importe, _ = regex.ParseImporte(m[1])
+		}
+		if m := reResultante.FindStringSubmatch(e.Texto); m != nil {
+			e.CapitalResultante, _ = regex.ParseImporte(m[1])
+		}
+	}
+}
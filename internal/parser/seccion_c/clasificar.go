@@ -0,0 +1,67 @@
+package seccionc
+
+import (
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+)
+
+// regla assigns a category to the announcements whose department or text
+// contains any of its keys, in the form of normalize.Key
+type regla struct {
+	tipo   models.TipoAnuncioC
+	claves []string
+}
+
+// reglasDepartamento classify by the BOE department heading, e.g.
+// "FUSIONES Y ABSORCIONES DE EMPRESAS"
+var reglasDepartamento = []regla{
+	{models.TipoCConvocatoriaJunta, []string{"CONVOCATORIA"}},
+	{models.TipoCFusion, []string{"FUSION", "ABSORCION"}},
+	{models.TipoCEscision, []string{"ESCISION"}},
+	{models.TipoCReduccionCapital, []string{"REDUCCION DE CAPITAL"}},
+	{models.TipoCTransformacion, []string{"TRANSFORMACION"}},
+	{models.TipoCDisolucion, []string{"DISOLUCION", "LIQUIDACION"}},
+	{models.TipoCCambioDomicilio, []string{"DOMICILIO"}},
+	{models.TipoCPerdidaCertificados, []string{"CERTIFICADO", "EXTRAVIO"}},
+}
+
+// reglasTexto classify the announcements under a generic heading ("OTROS
+// ANUNCIOS Y AVISOS LEGALES") by their title and first paragraph
+var reglasTexto = []regla{
+	{models.TipoCFusion, []string{"SOCIEDAD ABSORBENTE", "SOCIEDAD ABSORBIDA", "FUSION POR ABSORCION", "PROYECTO DE FUSION"}},
+	{models.TipoCEscision, []string{"SOCIEDAD ESCINDIDA", "SOCIEDAD BENEFICIARIA", "ESCISION"}},
+	{models.TipoCConvocatoriaJunta, []string{"CONVOCA A LOS", "CONVOCATORIA DE JUNTA", "SE CONVOCA"}},
+	{models.TipoCReduccionCapital, []string{"REDUCCION DE CAPITAL", "REDUCCION DEL CAPITAL"}},
+	{models.TipoCTransformacion, []string{"TRANSFORMACION"}},
+	{models.TipoCDisolucion, []string{"DISOLUCION", "LIQUIDACION"}},
+	{models.TipoCCambioDomicilio, []string{"TRASLADO DE DOMICILIO", "CAMBIO DE DOMICILIO"}},
+	{models.TipoCPerdidaCertificados, []string{"EXTRAVIO", "PERDIDA DE CERTIFICADO", "PERDIDA DEL CERTIFICADO", "PERDIDA DE LOS CERTIFICADOS"}},
+}
+
+// Classify returns the category of a Section C announcement from its BOE
+// department heading or, under a generic heading, from its title and the
+// first paragraph of its text
+func Classify(departamento, titulo, texto string) models.TipoAnuncioC {
+	if tipo, ok := aplicar(reglasDepartamento, normalize.Key(departamento)); ok {
+		return tipo
+	}
+	primero, _, _ := strings.Cut(texto, "\n")
+	if tipo, ok := aplicar(reglasTexto, normalize.Key(titulo+" "+primero)); ok {
+		return tipo
+	}
+	return models.TipoCOtros
+}
+
+// aplicar returns the category of the first rule with a key in s
+func aplicar(reglas []regla, s string) (models.TipoAnuncioC, bool) {
+	for _, r := range reglas {
+		for _, clave := range r.claves {
+			if strings.Contains(s, clave) {
+				return r.tipo, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,216 @@
+package seccionc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/regex"
+)
+
+var (
+	// reTipoJunta matches the kind of meeting, e.g. "Junta General Ordinaria
+	// y Extraordinaria"
+	reTipoJunta = regexp.MustCompile(`(?i)junta\s+general\s+(?:de\s+(?:socios|accionistas)\s+)?(ordinaria|extraordinaria)(?:\s+y\s+(ordinaria|extraordinaria))?`)
+	// reCaracterJunta matches "con carácter extraordinario"
+	reCaracterJunta = regexp.MustCompile(`(?i)con\s+car[aá]cter\s+(ordinario|extraordinario)`)
+
+	// reLlamada matches the mention of each call, e.g. "en primera convocatoria"
+	reLlamada = regexp.MustCompile(`(?i)\b(primera|segunda)\s+convocatoria`)
+	// reFechaSigue matches a date right after the mention of a call
+	reFechaSigue = regexp.MustCompile(`(?i)^[\s,:]*(?:el\s+(?:pr[oó]ximo\s+)?)?(?:d[ií]a\s+)?\d{1,2} de `)
+	reFecha      = regexp.MustCompile(`\b(\d{1,2} de \p{L}+ de \d{4})`)
+	reHora       = regexp.MustCompile(`(?i)\b(\d{1,2})(?:[:.,](\d{2}))?\s*(?:horas|h\b)`)
+
+	// reLugar matches the venue following "sito en", ending where the date
+	// or the call is given
+	reLugar = regexp.MustCompile(`(?i)\b(?:sit[oa]|situad[oa]|ubicad[oa])\s+en\s+(.+?),?\s+(?:el\s+(?:pr[oó]ximo\s+)?d[ií]a\b|a\s+las\b|en\s+primera\b)`)
+	// reLugarCelebracion matches the venue following "se celebrará en"
+	reLugarCelebracion = regexp.MustCompile(`(?i)\bcelebrar[aá]\s+en\s+(.+?),?\s+(?:el\s+(?:pr[oó]ximo\s+)?d[ií]a\b|a\s+las\b|en\s+primera\b)`)
+
+	// reOrdenDelDia matches the heading of the agenda
+	reOrdenDelDia = regexp.MustCompile(`(?i)\borden\s+del\s+d[ií]a\s*:?\s*`)
+	// rePunto matches the number of an agenda item, e.g. "Primero.-" or "2º."
+	rePunto = regexp.MustCompile(`(?i)(?:^|\s)(?:primer[oa]|segund[oa]|tercer[oa]|cuart[oa]|quint[oa]|sext[oa]|s[eé]ptim[oa]|octav[oa]|noven[oa]|d[eé]cim[oa]|und[eé]cim[oa]|duod[eé]cim[oa]|\d{1,2}\s*[º°ª]?)\s*(?:\.-|\.–|-|–|\.|\))\s+`)
+
+	// reConvocante matches the body calling the meeting, e.g. "Por acuerdo
+	// del Consejo de Administración se convoca"
+	reConvocante = regexp.MustCompile(`(?i)\bpor\s+acuerdo\s+de(?:l|\s+la|\s+los)?\s+(.+?)(?:\s+de\s+(?:la|esta)\s+(?:sociedad|compa[ñn][ií]a|entidad))?,?\s+se\s+convoca`)
+	// reOrgano matches a body convening in its own name, e.g. "El
+	// Administrador único convoca"
+	reOrgano = regexp.MustCompile(`(?i)\b(?:el|la|los)\s+(consejo\s+de\s+administraci[oó]n|administrador(?:es)?(?:\s+(?:[uú]nico|solidarios?|mancomunados?))?|liquidador(?:es)?(?:\s+[uú]nico)?)\b[^.]*?\bconvoca`)
+	// reFirma matches the role signing the announcement, e.g.
+	// "Sevilla, 18 de mayo de 2015.- El Presidente del Consejo, ..."
+	reFirma = regexp.MustCompile(`\d{4}\s*\.\s*-\s*(?:El|La|Los|Las)\s+([^,]+)`)
+)
+
+// tiposJunta maps the names of the kinds of meeting, lower-case
+var tiposJunta = map[string]models.TipoJunta{
+	"ordinaria":      models.JuntaOrdinaria,
+	"extraordinaria": models.JuntaExtraordinaria,
+	"ordinario":      models.JuntaOrdinaria,
+	"extraordinario": models.JuntaExtraordinaria,
+}
+
+// ParseConvocatoria extracts the kind of meeting, the date and time of each
+// call, the venue, the agenda and the convening body from the text of a
+// convocatoria de junta. Paragraphs are separated by "\n".
+func ParseConvocatoria(texto string) *models.Convocatoria {
+	c := &models.Convocatoria{
+		TipoJunta:   tipoJunta(texto),
+		Lugar:       lugar(texto),
+		OrdenDelDia: ordenDelDia(texto),
+		Convocante:  convocante(texto),
+	}
+	c.PrimeraConvocatoria, c.SegundaConvocatoria = llamadas(texto)
+	return c
+}
+
+func tipoJunta(texto string) models.TipoJunta {
+	if m := reTipoJunta.FindStringSubmatch(texto); m != nil {
+		tipo := tiposJunta[strings.ToLower(m[1])]
+		if m[2] != "" && !strings.EqualFold(m[1], m[2]) {
+			return models.JuntaOrdinariaExtraordinaria
+		}
+		return tipo
+	}
+	if m := reCaracterJunta.FindStringSubmatch(texto); m != nil {
+		return tiposJunta[strings.ToLower(m[1])]
+	}
+	return ""
+}
+
+// llamadas returns the date and time of the first and second calls. They
+// are given either before each mention ("el día 30 de junio de 2015, a las
+// 12:00 horas, en primera convocatoria") or, when a date follows the first
+// mention, after them. The second call may be "el día siguiente" and "a la
+// misma hora" of the first.
+func llamadas(texto string) (primera, segunda time.Time) {
+	menciones := reLlamada.FindAllStringSubmatchIndex(texto, -1)
+	if len(menciones) == 0 {
+		return primera, segunda
+	}
+	despues := reFechaSigue.MatchString(texto[menciones[0][1]:])
+
+	prevEnd := 0
+	for i, m := range menciones {
+		segmento := texto[prevEnd:m[0]]
+		if despues {
+			nextStart := len(texto)
+			if i+1 < len(menciones) {
+				nextStart = menciones[i+1][0]
+			}
+			segmento = texto[m[1]:nextStart]
+		}
+		prevEnd = m[1]
+
+		switch strings.ToLower(texto[m[2]:m[3]]) {
+		case "primera":
+			if primera.IsZero() {
+				primera = fechaHora(segmento, time.Time{}, despues)
+			}
+		case "segunda":
+			if segunda.IsZero() {
+				segunda = fechaHora(segmento, primera, despues)
+			}
+		}
+	}
+	return primera, segunda
+}
+
+// fechaHora returns the date and time of a call given in a segment of
+// text: the first ones when they follow its mention, the last ones when they
+// precede it. "el día siguiente" and "la misma hora" are relative to the
+// first call.
+func fechaHora(segmento string, primera time.Time, despues bool) time.Time {
+	elegir := func(n int) int {
+		if despues {
+			return 0
+		}
+		return n - 1
+	}
+
+	var fecha time.Time
+	if fechas := reFecha.FindAllString(segmento, -1); len(fechas) > 0 {
+		fecha = fechaLarga(fechas[elegir(len(fechas))])
+	} else if !primera.IsZero() && strings.Contains(strings.ToLower(segmento), "siguiente") {
+		fecha = time.Date(primera.Year(), primera.Month(), primera.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+	if fecha.IsZero() {
+		return fecha
+	}
+
+	if horas := reHora.FindAllStringSubmatch(segmento, -1); len(horas) > 0 {
+		h := horas[elegir(len(horas))]
+		hour, _ := strconv.Atoi(h[1])
+		minute, _ := strconv.Atoi(h[2])
+		if hour < 24 && minute < 60 {
+			return fecha.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		}
+	} else if !primera.IsZero() && strings.Contains(strings.ToLower(segmento), "misma hora") {
+		return fecha.Add(primera.Sub(primera.Truncate(24 * time.Hour)))
+	}
+	return fecha
+}
+
+// fechaLarga parses a date like "30 de junio de 2015", or returns the zero
+// time
+func fechaLarga(s string) time.Time {
+	fecha, err := regex.ParseFecha(s)
+	if err != nil {
+		return time.Time{}
+	}
+	return fecha
+}
+
+func lugar(texto string) string {
+	for _, re := range []*regexp.Regexp{reLugar, reLugarCelebracion} {
+		if m := re.FindStringSubmatch(texto); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	return ""
+}
+
+// ordenDelDia returns the agenda items following the "Orden del día"
+// heading. The last item ends with its paragraph.
+func ordenDelDia(texto string) []string {
+	loc := reOrdenDelDia.FindStringIndex(texto)
+	if loc == nil {
+		return nil
+	}
+	texto = texto[loc[1]:]
+
+	puntos := rePunto.FindAllStringIndex(texto, -1)
+	if len(puntos) == 0 || strings.TrimSpace(texto[:puntos[0][0]]) != "" {
+		return nil
+	}
+	var items []string
+	for i, p := range puntos {
+		end := len(texto)
+		if i+1 < len(puntos) {
+			end = puntos[i+1][0]
+		} else if nl := strings.IndexByte(texto[p[1]:], '\n'); nl >= 0 {
+			end = p[1] + nl
+		}
+		if item := strings.TrimSpace(texto[p[1]:end]); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func convocante(texto string) string {
+	if m := reConvocante.FindStringSubmatch(texto); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	if m := reOrgano.FindStringSubmatch(texto); m != nil {
+		return m[1]
+	}
+	if m := reFirma.FindStringSubmatch(texto); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
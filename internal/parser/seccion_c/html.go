@@ -0,0 +1,191 @@
+package seccionc
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// rePublicado matches the "Publicado en" metadata of a BOE page, e.g.
+// "BORME núm. 110, de 10 de junio de 2011, páginas 21622 a 21623 (2 págs.)"
+var rePublicado = regexp.MustCompile(`núm\.\s*(\d+),\s*de\s+(\d{1,2} de \p{L}+ de \d{4}),\s*páginas?\s+(\d+)(?:\s+a\s+(\d+))?`)
+
+// parseHTML parses a BOE announcement page (txt.php). The pages are HTML5
+// rather than well-formed XML (unclosed <p>, <dd> and <br>, named
+// entities), so they go through an HTML5 parser. The page holds the title
+// (h3.documento-tit), the metadata list (dt/dd), and the text (#textoxslt),
+// which opens with the company header.
+func (p *LxmlBormeCParser) parseHTML(content []byte) (*models.BormeC, error) {
+	doc, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	borme := models.NewBormeC()
+
+	if h3 := findElement(doc, func(n *html.Node) bool { return n.DataAtom == atom.H3 && hasClass(n, "documento-tit") }); h3 != nil {
+		borme.Titulo = normalize.Text(textContent(h3))
+	} else if title := findElement(doc, isAtom(atom.Title)); title != nil {
+		// "BOE.es - BORME-C-2011-20488 TITULO"
+		borme.Titulo = normalize.Text(strings.TrimPrefix(textContent(title), "BOE.es - "))
+		if fields := strings.SplitN(borme.Titulo, " ", 2); len(fields) == 2 && strings.HasPrefix(fields[0], "BORME-") {
+			borme.Titulo = fields[1]
+		}
+	}
+
+	for _, dt := range findElements(doc, isAtom(atom.Dt)) {
+		dd := nextElement(dt)
+		if dd == nil || dd.DataAtom != atom.Dd {
+			continue
+		}
+		value := normalize.Text(textContent(dd))
+		switch normalize.Key(strings.TrimSuffix(normalize.Text(textContent(dt)), ":")) {
+		case "PUBLICADO EN":
+			parsePublicado(borme, value)
+		case "SECCION":
+			if seccion, _, ok := strings.Cut(value, "."); ok {
+				borme.Seccion = models.Seccion(seccion)
+			}
+		case "DEPARTAMENTO":
+			borme.Departamento = value
+		case "REFERENCIA":
+			cve, err := models.ParseCVE(value)
+			if err != nil {
+				borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: err.Error(), Snippet: value})
+				continue
+			}
+			borme.CVE = cve
+			borme.NumeroAnuncio = strconv.Itoa(cve.Anuncio)
+		}
+	}
+
+	if pdf := findElement(doc, func(n *html.Node) bool {
+		return n.DataAtom == atom.A && n.Parent != nil && hasClass(n.Parent, "puntoPDF")
+	}); pdf != nil {
+		borme.URLPDF = attr(pdf, "href")
+	}
+
+	texto := findElement(doc, func(n *html.Node) bool { return attr(n, "id") == "textoxslt" })
+	if texto == nil {
+		texto = doc
+	}
+	cabecera := borme.Titulo
+	if h4 := findElement(texto, isAtom(atom.H4)); h4 != nil {
+		cabecera = strings.ReplaceAll(normalize.Text(textContent(h4)), "\n", " ")
+	}
+	var parrafos []string
+	for _, para := range findElements(texto, isAtom(atom.P)) {
+		if text := normalize.Text(textContent(para)); text != "" {
+			parrafos = append(parrafos, strings.ReplaceAll(text, "\n", " "))
+		}
+	}
+	borme.Texto = strings.Join(parrafos, "\n")
+	completar(borme, cabecera)
+
+	filename := p.filename
+	borme.Filename = &filename
+
+	return borme, nil
+}
+
+// parsePublicado sets the bulletin number, date and pages of the
+// "Publicado en" metadata
+func parsePublicado(borme *models.BormeC, value string) {
+	m := rePublicado.FindStringSubmatch(value)
+	if m == nil {
+		borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: "unrecognised publication data", Snippet: value})
+		return
+	}
+	borme.DiarioNumero, _ = strconv.Atoi(m[1])
+	if borme.Fecha = fechaLarga(m[2]); borme.Fecha.IsZero() {
+		borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: "invalid publication date", Snippet: m[2]})
+	}
+	borme.PaginaInicial, _ = strconv.Atoi(m[3])
+	borme.PaginaFinal = borme.PaginaInicial
+	if m[4] != "" {
+		borme.PaginaFinal, _ = strconv.Atoi(m[4])
+	}
+}
+
+func isAtom(a atom.Atom) func(*html.Node) bool {
+	return func(n *html.Node) bool { return n.DataAtom == a }
+}
+
+// findElements returns the elements under n matching match, in document order
+func findElements(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	var result []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && match(c) {
+			result = append(result, c)
+		}
+		result = append(result, findElements(c, match)...)
+	}
+	return result
+}
+
+// findElement returns the first element under n matching match, or nil
+func findElement(n *html.Node, match func(*html.Node) bool) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && match(c) {
+			return c
+		}
+		if found := findElement(c, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// nextElement returns the element following n, or nil
+func nextElement(n *html.Node) *html.Node {
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode {
+			return s
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent returns the text under n, with <br> as a line break
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.DataAtom == atom.Br:
+			b.WriteByte('\n')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -4,23 +4,43 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/regex"
 	"github.com/antchfx/xmlquery"
 )
 
 // LxmlBormeCParser parses Section C XML/HTML announcements
 type LxmlBormeCParser struct {
 	filename string
+	strict   bool
+}
+
+// Option configures a LxmlBormeCParser
+type Option func(*LxmlBormeCParser)
+
+// WithStrict makes Parse fail with a *models.DiagnosticsError when parsing
+// produced warnings or errors
+func WithStrict(strict bool) Option {
+	return func(p *LxmlBormeCParser) {
+		p.strict = strict
+	}
 }
 
 // NewParser creates a new Section C parser
-func NewParser(filename string) *LxmlBormeCParser {
-	return &LxmlBormeCParser{
+func NewParser(filename string, opts ...Option) *LxmlBormeCParser {
+	p := &LxmlBormeCParser{
 		filename: filename,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // Parse parses a Section C file (XML or HTML) and returns a BormeC object
@@ -39,151 +59,156 @@ func (p *LxmlBormeCParser) Parse() (*models.BormeC, error) {
 	}
 
 	// Detect format
+	var borme *models.BormeC
 	contentStr := string(content)
 	if strings.Contains(contentStr, "<?xml") || strings.Contains(contentStr, "<xml") {
-		return p.parseXML(content)
+		borme, err = p.parseXML(content)
+	} else {
+		borme, err = p.parseHTML(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.strict {
+		if diags := borme.Diagnostics.Filter(models.SeverityWarning); len(diags) > 0 {
+			return nil, &models.DiagnosticsError{Filename: p.filename, Diagnostics: diags}
+		}
 	}
 
-	return p.parseHTML(content)
+	return borme, nil
 }
 
-// parseXML parses XML format
+// parseXML parses a BOE documento XML:
+//
+//	<documento>
+//	  <metadatos><identificador>BORME-C-2011-20488</identificador>...</metadatos>
+//	  <texto><p>...</p></texto>
+//	</documento>
 func (p *LxmlBormeCParser) parseXML(content []byte) (*models.BormeC, error) {
 	doc, err := xmlquery.Parse(strings.NewReader(string(content)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
 
-	borme := models.NewBormeC()
-
-	// Extract fields from XML structure
-	// Common XML structure for BORME-C:
-	// <borme ...> or <diario ...>
-
-	// Extract departamento (department)
-	depto := xmlquery.FindOne(doc, "//departamento|//Departamento|//department")
-	if depto != nil {
-		borme.Departamento = strings.TrimSpace(depto.Data)
+	documento := xmlquery.FindOne(doc, "//documento")
+	if documento == nil {
+		return nil, fmt.Errorf("failed to parse XML: documento element not found")
 	}
 
-	// Extract texto (announcement text)
-	texto := xmlquery.FindOne(doc, "//texto|//Texto|//announcement_text")
-	if texto != nil {
-		borme.Texto = strings.TrimSpace(texto.Data)
-	}
+	borme := parseDocumento(documento)
+	filename := p.filename
+	borme.Filename = &filename
 
-	// Extract diario_numero (daily bulletin number)
-	nbo := xmlquery.FindOne(doc, "//diario_numero|//DiarioNumero|//nbo")
-	if nbo != nil {
-		fmt.Sscanf(nbo.Data, "%d", &borme.DiarioNumero)
-	}
+	return borme, nil
+}
 
-	// Extract numero_anuncio (announcement number)
-	numAnuncio := xmlquery.FindOne(doc, "//numero_anuncio|//NumeroAnuncio|//num")
-	if numAnuncio != nil {
-		borme.NumeroAnuncio = strings.TrimSpace(numAnuncio.Data)
-	}
+// reIDAnuncio matches the announcement ID closing the text, e.g. "ID: A110044738"
+var reIDAnuncio = regexp.MustCompile(`\bID:\s*([A-Z]\d+)`)
 
-	// Extract id_anuncio (full ID like "A110044738")
-	idAnuncio := xmlquery.FindOne(doc, "//id_anuncio|//IdAnuncio|//id")
-	if idAnuncio != nil {
-		borme.IDAnuncio = strings.TrimSpace(idAnuncio.Data)
-	}
+// reCIF matches a CIF, e.g. "B46123451" or "B-97654321"
+var reCIF = regexp.MustCompile(`\b([ABCDEFGHJNPQRSUVW])-?(\d{7})-?([0-9A-J])\b`)
 
-	// Extract CVE
-	cve := xmlquery.FindOne(doc, "//cve|//CVE|//verificacion")
-	if cve != nil {
-		borme.CVE = strings.TrimSpace(cve.Data)
-	}
+// reRolEmpresa matches the role following each company of a title, e.g.
+// "(SOCIEDAD ABSORBENTE)"
+var reRolEmpresa = regexp.MustCompile(`\((?:SOCIEDAD|SOCIEDADES|ENTIDAD|ENTIDADES)\b[^)]*\)`)
 
-	// Extract titulo (title)
-	titulo := xmlquery.FindOne(doc, "//titulo|//Titulo|//title")
-	if titulo != nil {
-		borme.Titulo = strings.TrimSpace(titulo.Data)
-	}
+// parseDocumento extracts an announcement from a documento element
+func parseDocumento(documento *xmlquery.Node) *models.BormeC {
+	borme := models.NewBormeC()
 
-	// Extract empresa (company name)
-	empresa := xmlquery.FindOne(doc, "//empresa|//Empresa|//company")
-	if empresa != nil {
-		borme.Empresa = strings.TrimSpace(empresa.Data)
+	metadatos := xmlquery.FindOne(documento, "metadatos")
+	if metadatos == nil {
+		metadatos = documento
 	}
-
-	// Extract CIFs
-	cifs := xmlquery.Find(doc, "//cif|//CIF|//nif")
-	for _, cif := range cifs {
-		if cif.Data != "" {
-			borme.AddCIF(strings.TrimSpace(cif.Data))
+	field := func(name string) string {
+		if n := xmlquery.FindOne(metadatos, name); n != nil {
+			return normalize.Text(n.InnerText())
 		}
+		return ""
 	}
 
-	// Extract empresas_relacionadas (related companies for mergers)
-	relacionadas := xmlquery.Find(doc, "//empresas_relacionadas|//relacionada|//related_company")
-	for _, rel := range relacionadas {
-		if rel.Data != "" {
-			borme.AddEmpresaRelacionada(strings.TrimSpace(rel.Data))
+	if id := field("identificador"); id != "" {
+		cve, err := models.ParseCVE(id)
+		if err != nil {
+			borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: err.Error(), Snippet: id})
+		} else {
+			borme.CVE = cve
+			borme.NumeroAnuncio = strconv.Itoa(cve.Anuncio)
 		}
 	}
-
-	// Extract pages
-	paginaIni := xmlquery.FindOne(doc, "//pagina_inicial|//pagina")
-	if paginaIni != nil {
-		fmt.Sscanf(paginaIni.Data, "%d", &borme.PaginaInicial)
-	}
-
-	// Extract fecha (date)
-	fecha := xmlquery.FindOne(doc, "//fecha|//Fecha|//date")
-	if fecha != nil {
-		if t, err := time.Parse("2006-01-02", fecha.Data); err == nil {
+	if seccion := field("seccion"); seccion != "" {
+		borme.Seccion = models.Seccion(seccion)
+	}
+	borme.Titulo = field("titulo")
+	borme.Departamento = field("departamento")
+	borme.URLPDF = field("url_pdf")
+	borme.DiarioNumero, _ = strconv.Atoi(field("diario_numero"))
+	borme.PaginaInicial, _ = strconv.Atoi(field("pagina_inicial"))
+	borme.PaginaFinal, _ = strconv.Atoi(field("pagina_final"))
+	if fecha := field("fecha_publicacion"); fecha != "" {
+		if t, err := time.Parse("20060102", fecha); err == nil {
 			borme.Fecha = t
+		} else {
+			borme.AddDiagnostic(models.Diagnostic{Severity: models.SeverityWarning, Message: "invalid fecha_publicacion", Snippet: fecha})
 		}
 	}
 
-	// Set filename
-	filename := p.filename
-	borme.Filename = &filename
-
-	return borme, nil
-}
-
-// parseHTML parses HTML format
-func (p *LxmlBormeCParser) parseHTML(content []byte) (*models.BormeC, error) {
-	doc, err := xmlquery.Parse(strings.NewReader(string(content)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	var parrafos []string
+	for _, para := range xmlquery.Find(documento, "texto//p") {
+		if text := normalize.Text(para.InnerText()); text != "" {
+			parrafos = append(parrafos, strings.ReplaceAll(text, "\n", " "))
+		}
 	}
+	borme.Texto = strings.Join(parrafos, "\n")
+	completar(borme, borme.Titulo)
 
-	borme := models.NewBormeC()
-
-	// Extract from HTML structure
-	// Common elements in BORME-C HTML
+	return borme
+}
 
-	// Extract titulo
-	titulo := xmlquery.FindOne(doc, "//h1|//h2|//h3|//title")
-	if titulo != nil {
-		borme.Titulo = strings.TrimSpace(titulo.Data)
+// completar sets the fields found in the text of an announcement (ID and
+// CIFs), its category, the convocatoria of a junta and its companies,
+// listed in the given header
+func completar(borme *models.BormeC, cabecera string) {
+	borme.Tipo = Classify(borme.Departamento, borme.Titulo, borme.Texto)
+	if borme.Tipo == models.TipoCConvocatoriaJunta {
+		borme.Convocatoria = ParseConvocatoria(borme.Texto)
+	}
+	if m := reIDAnuncio.FindStringSubmatch(borme.Texto); m != nil {
+		borme.IDAnuncio = m[1]
+	}
+	seen := make(map[string]bool)
+	for _, m := range reCIF.FindAllStringSubmatch(borme.Texto, -1) {
+		if cif := m[1] + m[2] + m[3]; !seen[cif] {
+			seen[cif] = true
+			borme.AddCIF(cif)
+		}
 	}
 
-	// Extract texto from paragraph elements
-	paras := xmlquery.Find(doc, "//p|//div[@class='texto']")
-	for _, para := range paras {
-		borme.Texto += " " + strings.TrimSpace(para.Data)
+	empresas := splitTitulo(cabecera)
+	if len(empresas) > 0 {
+		borme.Empresa = empresas[0]
+		for _, e := range empresas[1:] {
+			borme.AddEmpresaRelacionada(e)
+		}
 	}
-	borme.Texto = strings.TrimSpace(borme.Texto)
+}
 
-	// Extract empresa from headers or specific elements
-	empresa := xmlquery.FindOne(doc, "//strong|//b|//span[@class='empresa']")
-	if empresa != nil {
-		borme.Empresa = strings.TrimSpace(empresa.Data)
+// splitTitulo returns the companies of an announcement title. Titles of
+// fusiones and escisiones list several companies, each followed by its
+// role: "ALFA, S.L. (SOCIEDAD ABSORBENTE) Y BETA, S.A. (SOCIEDAD ABSORBIDA)".
+func splitTitulo(titulo string) []string {
+	var empresas []string
+	for _, part := range reRolEmpresa.Split(titulo, -1) {
+		part = strings.TrimPrefix(strings.TrimLeft(part, ", "), "Y ")
+		if part = regex.TrimFinalDot(part); part != "" {
+			empresas = append(empresas, part)
+		}
 	}
-
-	// Set filename
-	filename := p.filename
-	borme.Filename = &filename
-
-	return borme, nil
+	return empresas
 }
 
-// ParseMultipleXML parses multiple announcements from an XML file
+// ParseMultipleXML parses every documento element of an XML file
 func ParseMultipleXML(filename string) ([]models.BormeC, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -196,41 +221,15 @@ func ParseMultipleXML(filename string) ([]models.BormeC, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Try to parse as XML
 	doc, err := xmlquery.Parse(strings.NewReader(string(content)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
 
 	var results []models.BormeC
-
-	// Find all announcement elements
-	anuncios := xmlquery.Find(doc, "//anuncio|//Anuncio|//announcement")
-
-	for _, anuncio := range anuncios {
-		borme := models.NewBormeC()
-
-		// Extract fields from each announcement
-		depto := xmlquery.FindOne(anuncio, "./departamento|./Departamento")
-		if depto != nil {
-			borme.Departamento = strings.TrimSpace(depto.Data)
-		}
-
-		texto := xmlquery.FindOne(anuncio, "./texto|./Texto")
-		if texto != nil {
-			borme.Texto = strings.TrimSpace(texto.Data)
-		}
-
-		empresa := xmlquery.FindOne(anuncio, "./empresa|./Empresa")
-		if empresa != nil {
-			borme.Empresa = strings.TrimSpace(empresa.Data)
-		}
-
-		cve := xmlquery.FindOne(anuncio, "./cve|./CVE")
-		if cve != nil {
-			borme.CVE = strings.TrimSpace(cve.Data)
-		}
-
+	for _, documento := range xmlquery.Find(doc, "//documento") {
+		borme := parseDocumento(documento)
+		borme.Filename = &filename
 		results = append(results, *borme)
 	}
 
@@ -3,8 +3,14 @@ package regex
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/normalize"
 )
 
 // Compiled regex patterns from Python's regex.py
@@ -15,18 +21,34 @@ var REGEX_EMPRESA = regexp.MustCompile(`^(\d+) - (.*?)\.?$`)
 // REGEX_EMPRESA_REGISTRO matches company with register like "57344 - ALDARA CATERING SL(R.M. Madrid)"
 var REGEX_EMPRESA_REGISTRO = regexp.MustCompile(`^(\d+) - (.*)\(R\.M\. (.*)\)\.?$`)
 
+// REGEX_CABECERA matches the start of an anuncio header like "57344 - ALDARA CATERING SL"
+var REGEX_CABECERA = regexp.MustCompile(`^(\d+)\s*-\s*(.+)$`)
+
+// REGEX_EMPRESA_RM matches the register suffix "(R.M. Madrid)" and variants such as "(R.M.: Madrid)."
+var REGEX_EMPRESA_RM = regexp.MustCompile(`(?i)\s*\(R\.\s*M\.?\s*:?\s*(?:de\s+)?([^)]*)\)\s*\.?\s*$`)
+
+// REGEX_EMPRESA_LIQUIDACION matches the "EN LIQUIDACION" company name suffix
+var REGEX_EMPRESA_LIQUIDACION = regexp.MustCompile(`(?i)[\s,]*\bEN\s+LIQUIDACI(?:O|Ó)N\.?$`)
+
+// REGEX_EMPRESA_SUCURSAL matches the "SUCURSAL EN ESPAÑA" company name suffix
+var REGEX_EMPRESA_SUCURSAL = regexp.MustCompile(`(?i)[\s,]*\bSUCURSAL\s+EN\s+ESPA(?:N|Ñ)A\.?$`)
+
 // REGEX_PDF_TEXT matches PDF text markers like "(...)Tj"
 var REGEX_PDF_TEXT = regexp.MustCompile(`^\((.*)\)Tj$`)
 
 // REGEX_BORME_NUM matches BORME number like "Núm. 57344"
 var REGEX_BORME_NUM = regexp.MustCompile(`^Núm\. (\d+)`)
 
-// REGEX_BORME_FECHA matches date format like "Martes 2 de junio de 2015"
-var REGEX_BORME_FECHA = regexp.MustCompile(`^\w+ (\d+) de (\w+) de (\d+)`)
+// REGEX_BORME_FECHA matches date format like "Martes 2 de junio de 2015",
+// the weekday being optional ("2 de junio de 2015")
+var REGEX_BORME_FECHA = regexp.MustCompile(`^(?:\p{L}+,? )?(\d+) de (\p{L}+) de (\d+)`)
 
 // REGEX_BORME_CVE matches CVE identifier like "cve: BORME-A-2015-101-29"
 var REGEX_BORME_CVE = regexp.MustCompile(`^cve: (.*)$`)
 
+// REGEX_IMPORTE matches an amount in Spanish notation like "3.000,00 Euros"
+var REGEX_IMPORTE = regexp.MustCompile(`\d{1,3}(?:\.\d{3})+(?:,\d+)?|\d+(?:,\d+)?`)
+
 // REGEX_ARGCOLON matches acto with colon argument (e.g., "Capital: 3.000 EUR")
 var REGEX_ARGCOLON = regexp.MustCompile(`^(.*):\s*(.*)$`)
 
@@ -44,10 +66,13 @@ var RE_CARGOS_SEPARADOR = regexp.MustCompile(`;\s*`)
 
 // Regex empresa result
 type EmpresaMatch struct {
-	ID        string
-	Name      string
-	Extra     string
-	Registro  string
+	ID          string
+	Name        string
+	Extra       string
+	Registro    string
+	Sucursal    bool
+	Liquidacion bool
+	Normalizada string
 }
 
 // RegexCargosResult represents parsed cargo assignments
@@ -90,8 +115,103 @@ func ParseEmpresa(s string) (id string, name string, registro map[string]string)
 	return "", s, nil
 }
 
+// ParseCabecera parses an anuncio header such as
+// "57344 - ACME GMBH SUCURSAL EN ESPAÑA EN LIQUIDACION(R.M. Madrid)."
+// Name keeps the name as published; Normalizada drops the sucursal and
+// liquidación suffixes and compacts the legal form. Returns nil if s is not a header.
+func ParseCabecera(s string) *EmpresaMatch {
+	match := REGEX_CABECERA.FindStringSubmatch(strings.Join(strings.Fields(s), " "))
+	if match == nil {
+		return nil
+	}
+
+	m := &EmpresaMatch{ID: match[1]}
+	name := match[2]
+	if rm := REGEX_EMPRESA_RM.FindStringSubmatchIndex(name); rm != nil {
+		m.Registro = strings.TrimSpace(name[rm[2]:rm[3]])
+		name = name[:rm[0]]
+	}
+	m.Name = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), "."))
+
+	_, m.Sucursal, m.Liquidacion = splitEmpresaFlags(m.Name)
+	m.Normalizada = NormalizeEmpresa(m.Name)
+	return m
+}
+
+// CabeceraCompleta reports whether s is a whole anuncio header: one that
+// ParseCabecera recognises and that ends with the register suffix "(R.M. X)"
+// or with its final dot. The dot of a legal form split across lines
+// ("ACME S." followed by "L.") does not end the header.
+func CabeceraCompleta(s string) bool {
+	s = strings.TrimSpace(s)
+	if ParseCabecera(s) == nil {
+		return false
+	}
+	if REGEX_EMPRESA_RM.MatchString(s) {
+		return true
+	}
+	if !strings.HasSuffix(s, ".") {
+		return false
+	}
+
+	words := strings.Fields(strings.TrimSuffix(s, "."))
+	if !inicial(words[len(words)-1]) {
+		return true
+	}
+	// "S. L." is complete, "ACME S." is not
+	return len(words) > 1 && strings.HasSuffix(words[len(words)-2], ".") && inicial(words[len(words)-2])
+}
+
+// inicial reports whether a word is a single letter, with or without its dot
+func inicial(w string) bool {
+	return utf8.RuneCountInString(strings.TrimSuffix(w, ".")) == 1
+}
+
+// NormalizeEmpresa returns a company name in a canonical form for display
+// and matching: upper case, without sucursal/liquidación suffixes and with
+// the legal form compacted ("Acme, S.L. en liquidación" -> "ACME SL")
+func NormalizeEmpresa(name string) string {
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	upper = strings.TrimSpace(strings.TrimSuffix(upper, "."))
+	upper, _, _ = splitEmpresaFlags(upper)
+
+	words, k, forma := splitFormaJuridica(upper)
+	if k > 0 {
+		words = append(words[:len(words)-k], string(forma))
+	}
+	return strings.Join(words, " ")
+}
+
+// EmpresaFlags reports whether a company name carries the "SUCURSAL EN
+// ESPAÑA" and "EN LIQUIDACION" suffixes
+func EmpresaFlags(name string) (sucursal, liquidacion bool) {
+	_, sucursal, liquidacion = splitEmpresaFlags(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), ".")))
+	return sucursal, liquidacion
+}
+
+// splitEmpresaFlags removes the "SUCURSAL EN ESPAÑA" and "EN LIQUIDACION"
+// suffixes, in any order, and reports which ones were found
+func splitEmpresaFlags(name string) (base string, sucursal, liquidacion bool) {
+	base = name
+	for {
+		switch {
+		case REGEX_EMPRESA_LIQUIDACION.MatchString(base):
+			liquidacion = true
+			base = REGEX_EMPRESA_LIQUIDACION.ReplaceAllString(base, "")
+		case REGEX_EMPRESA_SUCURSAL.MatchString(base):
+			sucursal = true
+			base = REGEX_EMPRESA_SUCURSAL.ReplaceAllString(base, "")
+		default:
+			return strings.TrimSpace(base), sucursal, liquidacion
+		}
+	}
+}
+
 // ParseCargos parses cargo assignments like "Adm. Solid.: JUAN PEREZ;MARIA GARCIA"
 // Returns: map[cargo_type][]person_names
+//
+// Deprecated: use cargos.Parse, which keeps cargo order and handles accented
+// and dotted abbreviations such as "Cons.Del.Man" or "Liquidador M.".
 func ParseCargos(s string) map[string][]string {
 	result := make(map[string][]string)
 
@@ -148,11 +268,43 @@ func ParseNoArg(s string) string {
 	return s
 }
 
+// reTrailingAbbrev matches text ending with an abbreviation whose final
+// dot belongs to it (e.g. "ACME S.L." or "ACME S. L.")
+var reTrailingAbbrev = regexp.MustCompile(`\b\p{L}\. ?\p{L}\.$`)
+
+// TrimFinalDot removes the dot closing a sentence, keeping the dot of a
+// trailing abbreviation ("ACME SL." -> "ACME SL", "ACME S.L." and
+// "ACME S. L." unchanged)
+func TrimFinalDot(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, ".") && !reTrailingAbbrev.MatchString(s) {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "."))
+	}
+	return s
+}
+
+// ParseImporte parses the first amount in Spanish notation found in s
+// ("Capital: 3.000,00 Euros" -> 3000). Returns false if there is none.
+func ParseImporte(s string) (float64, bool) {
+	match := REGEX_IMPORTE.FindString(s)
+	if match == "" {
+		return 0, false
+	}
+	match = strings.ReplaceAll(match, ".", "")
+	match = strings.ReplaceAll(match, ",", ".")
+	v, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
 // ParseFecha parses Spanish date format like "Martes 2 de junio de 2015"
+// or "2 de junio de 2015"
 func ParseFecha(s string) (time.Time, error) {
 	match := REGEX_BORME_FECHA.FindStringSubmatch(s)
 	if match == nil || len(match) < 4 {
-		return time.Time{}, nil
+		return time.Time{}, fmt.Errorf("invalid date: %q", s)
 	}
 
 	day := match[1]
@@ -178,23 +330,27 @@ func ParseFecha(s string) (time.Time, error) {
 
 	month, ok := monthMap[strings.ToLower(monthStr)]
 	if !ok {
-		return time.Time{}, nil
+		return time.Time{}, fmt.Errorf("invalid month %q in date %q", monthStr, s)
 	}
 
 	// Parse day and year
 	var dayInt int
 	_, err := fmt.Sscanf(day, "%d", &dayInt)
 	if err != nil {
-		return time.Time{}, nil
+		return time.Time{}, fmt.Errorf("invalid day in date %q: %w", s, err)
 	}
 
 	var yearInt int
 	_, err = fmt.Sscanf(year, "%d", &yearInt)
 	if err != nil {
-		return time.Time{}, nil
+		return time.Time{}, fmt.Errorf("invalid year in date %q: %w", s, err)
 	}
 
-	return time.Date(yearInt, time.Month(month), dayInt, 0, 0, 0, 0, time.UTC), nil
+	date := time.Date(yearInt, time.Month(month), dayInt, 0, 0, 0, 0, time.UTC)
+	if date.Day() != dayInt {
+		return time.Time{}, fmt.Errorf("invalid day in date %q", s)
+	}
+	return date, nil
 }
 
 // Acto types that take cargo arguments
@@ -247,6 +403,48 @@ func IsActoColon(actoType string) bool {
 	return actosConColon[actoType]
 }
 
+// Acto types with free text arguments
+var actosTexto = map[string]bool{
+	"Ampliación de capital":                                true,
+	"Reducción de capital":                                 true,
+	"Modificaciones estatutarias":                          true,
+	"Cambio de denominación social":                        true,
+	"Cambio de domicilio social":                           true,
+	"Ampliacion del objeto social":                         true,
+	"Cambio de objeto social":                              true,
+	"Situación concursal":                                  true,
+	"Declaración de concurso":                              true,
+	"Apertura de fase de liquidación":                      true,
+	"Resoluciones judiciales":                              true,
+	"Transformación de sociedad":                           true,
+	"Fusión por absorción":                                 true,
+	"Escisión parcial":                                     true,
+	"Cesión global de activo y pasivo":                     true,
+	"Pérdida del caracter de unipersonalidad":              true,
+	"Datos registrales":                                    true,
+	"Otros conceptos":                                      true,
+	"Emisión de obligaciones":                              true,
+	"Reactivación de la sociedad":                          true,
+	"Primera inscripción":                                  true,
+	"Cierre provisional hoja registral":                    true,
+	"Reapertura hoja registral":                            true,
+	"Anotación preventiva":                                 true,
+	"Desembolso de dividendos pasivos":                     true,
+	"Depósito de libros":                                   true,
+	"Adaptación Ley 2/95":                                  true,
+	"Adaptación Ley 44/2015":                               true,
+	"Empresario Individual":                                true,
+	"Modificación de poderes":                              true,
+	"Acuerdo de ampliación de capital social sin ejecutar": true,
+}
+
+// IsActoConocido returns true if the acto type is in any of the known acto lists
+func IsActoConocido(actoType string) bool {
+	_, cargo := actosConCargo[actoType]
+	return cargo || actosSinArg[actoType] || actosConColon[actoType] ||
+		actosBold[actoType] || actosTexto[actoType]
+}
+
 // Bold acto types
 var actosBold = map[string]bool{
 	"Declaración de unipersonalidad": true,
@@ -260,43 +458,198 @@ func IsActoBold(actoType string) bool {
 	return actosBold[actoType]
 }
 
-// IsCompany returns true if the entity is a company (has SL/SA suffix)
+// formasJuridicas maps legal form suffixes, written without dots or spaces,
+// to the detected form. "S.L.", "S. L." and "SL" all compact to "SL".
+var formasJuridicas = map[string]models.FormaJuridica{
+	"SL":                           models.FormaJuridicaSL,
+	"SOCIEDADLIMITADA":             models.FormaJuridicaSL,
+	"SRL":                          models.FormaJuridicaSRL,
+	"SLU":                          models.FormaJuridicaSLU,
+	"SOCIEDADLIMITADAUNIPERSONAL":  models.FormaJuridicaSLU,
+	"SLL":                          models.FormaJuridicaSLL,
+	"SOCIEDADLIMITADALABORAL":      models.FormaJuridicaSLL,
+	"SLP":                          models.FormaJuridicaSLP,
+	"SOCIEDADLIMITADAPROFESIONAL":  models.FormaJuridicaSLP,
+	"SLNE":                         models.FormaJuridicaSLNE,
+	"SOCIEDADLIMITADANUEVAEMPRESA": models.FormaJuridicaSLNE,
+	"SA":                           models.FormaJuridicaSA,
+	"SOCIEDADANONIMA":              models.FormaJuridicaSA,
+	"SAU":                          models.FormaJuridicaSAU,
+	"SOCIEDADANONIMAUNIPERSONAL":   models.FormaJuridicaSAU,
+	"SAL":                          models.FormaJuridicaSAL,
+	"SOCIEDADANONIMALABORAL":       models.FormaJuridicaSAL,
+	"SCOOP":                        models.FormaJuridicaSCOOP,
+	"SOCIEDADCOOPERATIVA":          models.FormaJuridicaSCOOP,
+	"AIE":                          models.FormaJuridicaAIE,
+	"AGRUPACIONDEINTERESECONOMICO": models.FormaJuridicaAIE,
+	"SCP":                          models.FormaJuridicaSC,
+	"SOCIEDADCIVIL":                models.FormaJuridicaSC,
+	"SOCIEDADCIVILPARTICULAR":      models.FormaJuridicaSC,
+	"CB":                           models.FormaJuridicaCB,
+	"COMUNIDADDEBIENES":            models.FormaJuridicaCB,
+	"GMBH":                         models.FormaJuridicaGMBH,
+	"GMBH&COKG":                    models.FormaJuridicaGMBH,
+	"AG":                           models.FormaJuridicaAG,
+	"LTD":                          models.FormaJuridicaLTD,
+	"LIMITED":                      models.FormaJuridicaLTD,
+	"PLC":                          models.FormaJuridicaPLC,
+	"LLC":                          models.FormaJuridicaLLC,
+	"INC":                          models.FormaJuridicaINC,
+	"CORP":                         models.FormaJuridicaINC,
+	"CORPORATION":                  models.FormaJuridicaINC,
+	"BV":                           models.FormaJuridicaBV,
+	"NV":                           models.FormaJuridicaNV,
+	"SARL":                         models.FormaJuridicaSARL,
+	"SAS":                          models.FormaJuridicaSAS,
+	"SPA":                          models.FormaJuridicaSPA,
+	"LDA":                          models.FormaJuridicaLDA,
+	"AB":                           models.FormaJuridicaAB,
+}
+
+// entidadesPrefijos are name prefixes of legal entities that carry no legal form suffix
+var entidadesPrefijos = []string{
+	"FUNDACION ", "ASOCIACION ", "AYUNTAMIENTO ", "CONSORCIO ", "MUTUA ",
+	"CAJA DE AHORROS ", "COMUNIDAD DE REGANTES ", "FONDO DE ",
+}
+
+// FormaJuridica detects the legal form of a company from its name
+// (e.g. "ACME, S.L.U." -> SLU). Returns "" if no legal form is found.
+func FormaJuridica(name string) models.FormaJuridica {
+	words, _, forma := splitFormaJuridica(strings.ToUpper(name))
+	if forma != "" {
+		return forma
+	}
+
+	// Cooperatives carry regional suffixes: "S.COOP.AND.", "S. COOP. V."
+	for _, w := range words[min(1, len(words)):] {
+		w = strings.ReplaceAll(w, ".", "")
+		if strings.HasPrefix(w, "SCOOP") || w == "COOP" || w == "COOPERATIVA" {
+			return models.FormaJuridicaSCOOP
+		}
+	}
+
+	return ""
+}
+
+// splitFormaJuridica splits an upper-cased company name into words and
+// returns the legal form found in its last k words
+func splitFormaJuridica(upper string) (words []string, k int, forma models.FormaJuridica) {
+	words = strings.FieldsFunc(upper, func(r rune) bool { return r == ' ' || r == ',' })
+
+	// Try the longest suffix first: "SOCIEDAD LIMITADA LABORAL" before "LABORAL"
+	for k = min(len(words)-1, 4); k >= 1; k-- {
+		suffix := strings.ReplaceAll(normalize.FoldAccents(strings.Join(words[len(words)-k:], "")), ".", "")
+		if forma, ok := formasJuridicas[suffix]; ok && (len(suffix) > 2 || dotted(words[len(words)-k:])) {
+			return words, k, forma
+		}
+	}
+	return words, 0, ""
+}
+
+// dotted reports whether the words spelling a two-letter legal form are a
+// single token ("AB", "A.G.") or dotted initials ("S. A."), so that the
+// initials of a person ("LARSSON PER A B") are not read as a legal form.
+// The last word may have lost its final dot.
+func dotted(words []string) bool {
+	for _, w := range words[:len(words)-1] {
+		if !strings.HasSuffix(w, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// ClassifyEntity tells whether a name belongs to a natural person or a
+// legal entity, and returns the detected legal form for companies
+func ClassifyEntity(name string) (models.EntityKind, models.FormaJuridica) {
+	if forma := FormaJuridica(name); forma != "" {
+		return models.EntityEmpresa, forma
+	}
+	upper := normalize.FoldAccents(strings.ToUpper(strings.TrimSpace(name)))
+	for _, prefix := range entidadesPrefijos {
+		if strings.HasPrefix(upper, prefix) {
+			return models.EntityEmpresa, ""
+		}
+	}
+	return models.EntityPersona, ""
+}
+
+// IsCompany returns true if the entity is a company (has a legal form suffix
+// such as SL or SA, or is a known kind of legal entity)
 func IsCompany(name string) bool {
-	upper := strings.ToUpper(name)
-	return strings.HasSuffix(upper, " SL") ||
-		strings.HasSuffix(upper, ", SL") ||
-		strings.HasSuffix(upper, " S.L.") ||
-		strings.HasSuffix(upper, " SA") ||
-		strings.HasSuffix(upper, ", SA") ||
-		strings.HasSuffix(upper, " S.A.") ||
-		strings.HasSuffix(upper, " S.L.L.") ||
-		strings.HasSuffix(upper, " S.A.L.")
-}
-
-// CleanPDFText removes PDF encoding artifacts from text
+	kind, _ := ClassifyEntity(name)
+	return kind == models.EntityEmpresa
+}
+
+const softHyphen = string(normalize.SoftHyphen)
+
+// JoinLines joins the lines of a text wrapped across lines, columns or
+// pages. A word hyphenated at the end of a line is rejoined when the next
+// line starts in lower case ("adminis-" + "tración"); upper-case names such
+// as "GARCIA-" + "LOPEZ" keep their hyphen. Soft hyphens always rejoin.
+func JoinLines(lines []string) string {
+	var b strings.Builder
+	// The previous line is written once the next one tells how they join
+	prev := ""
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if prev != "" {
+			last, size := utf8.DecodeLastRuneInString(prev)
+			switch {
+			case last == normalize.SoftHyphen:
+				b.WriteString(prev[:len(prev)-size])
+			case last == '-' && guionFinal(prev[:len(prev)-size]) && startsLower(line):
+				b.WriteString(prev[:len(prev)-size])
+			case last == '-':
+				b.WriteString(prev)
+			default:
+				b.WriteString(prev)
+				b.WriteByte(' ')
+			}
+		}
+		prev = line
+	}
+	b.WriteString(strings.TrimSuffix(prev, softHyphen))
+	return b.String()
+}
+
+// guionFinal reports whether the text before a line-final hyphen ends in a
+// letter, i.e. the hyphen splits a word
+func guionFinal(s string) bool {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
+// startsLower reports whether s starts with a lower-case letter
+func startsLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// CleanPDFText removes PDF encoding artifacts from text, decoding octal
+// escapes as WinAnsi
 func CleanPDFText(s string) string {
+	return CleanPDFTextEncoding(s, normalize.WinAnsi)
+}
+
+// CleanPDFTextEncoding removes PDF encoding artifacts from text: the Tj
+// marker, escape sequences (decoded with enc, WinAnsi if nil), ligatures,
+// soft hyphens and repeated spaces
+func CleanPDFTextEncoding(s string, enc *normalize.Encoding) string {
 	// Remove Tj markers if present
 	if match := REGEX_PDF_TEXT.FindStringSubmatch(s); match != nil {
 		s = match[1]
 	}
 
-	// Unescape PDF special characters
-	s = strings.ReplaceAll(s, "\\(", "(")
-	s = strings.ReplaceAll(s, "\\)", ")")
-	s = strings.ReplaceAll(s, "\\ ", " ")
-
-	// Remove double spaces
-	for strings.Contains(s, "  ") {
-		s = strings.ReplaceAll(s, "  ", " ")
-	}
-
-	return strings.TrimSpace(s)
+	return normalize.Text(normalize.UnescapePDF(s, enc))
 }
 
 // CapitalizeSentence capitalizes the first letter of a sentence
 func CapitalizeSentence(s string) string {
-	if s == "" {
-		return s
-	}
-	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+	return normalize.Capitalize(s)
 }
@@ -0,0 +1,268 @@
+package gormeparser_test
+
+import (
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/nombres"
+	"github.com/argami/gormeparser/internal/parser"
+	"github.com/argami/gormeparser/internal/parser/actos"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Typed actos", func() {
+	ginkgo.Describe("Reestructuraciones", func() {
+		ginkgo.It("should split company lists on the final dot of each name", func() {
+			gomega.Expect(actos.SplitEmpresas("ALFA S.L. BETA SA.")).To(gomega.Equal([]string{"ALFA S.L.", "BETA SA"}))
+			gomega.Expect(actos.SplitEmpresas("ALFA S. L. BETA, S.A.")).To(gomega.Equal([]string{"ALFA S. L.", "BETA, S.A."}))
+			gomega.Expect(actos.SplitEmpresas("ALFA SL; BETA SL.")).To(gomega.Equal([]string{"ALFA SL", "BETA SL"}))
+		})
+
+		ginkgo.It("should give the anuncio's company the opposite role", func() {
+			acto := actos.ParseReestructuracion("Fusión por absorción",
+				"Sociedades absorbidas: ALFA SL. BETA SA.", "GRUPO ALFA SL")
+			gomega.Expect(acto.Tipo).To(gomega.Equal(models.TipoFusion))
+			gomega.Expect(acto.Empresas(models.RolAbsorbida)).To(gomega.Equal([]string{"ALFA SL", "BETA SA"}))
+			gomega.Expect(acto.Empresas(models.RolAbsorbente)).To(gomega.Equal([]string{"GRUPO ALFA SL"}))
+			gomega.Expect(acto.Participantes[2].Anuncio).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should mark the anuncio's company when it is listed", func() {
+			acto := actos.ParseReestructuracion("Fusión",
+				"Sociedad absorbente: GRUPO ALFA, S.L. Sociedades absorbidas: ALFA SL.", "GRUPO ALFA SL")
+			gomega.Expect(acto.Participantes).To(gomega.HaveLen(2))
+			gomega.Expect(acto.Participantes[0].Rol).To(gomega.Equal(models.RolAbsorbente))
+			gomega.Expect(acto.Participantes[0].Anuncio).To(gomega.BeTrue())
+			gomega.Expect(acto.Participantes[1].Anuncio).To(gomega.BeFalse())
+		})
+
+		ginkgo.It("should keep only the text when no roles are given", func() {
+			acto := actos.ParseReestructuracion("Escisión total", "Acuerdo de escisión.", "ALFA SL")
+			gomega.Expect(acto.Tipo).To(gomega.Equal(models.TipoEscisionTotal))
+			gomega.Expect(acto.Texto).To(gomega.Equal("Acuerdo de escisión."))
+			gomega.Expect(acto.Participantes).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should be parsed from Section A anuncios", func() {
+			borme, err := pypdf2.NewParser("testdata/reestructuraciones.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			absorbente := borme.Anuncios[60001]
+			fusion := absorbente.Actos[0].(*models.BormeActoReestructuracion)
+			gomega.Expect(fusion.Empresas(models.RolAbsorbente)).To(gomega.Equal([]string{"GRUPO LEVANTE INVERSIONES SL"}))
+			gomega.Expect(absorbente.EmpresasRelacionadas()).To(gomega.Equal([]string{"LEVANTE LOGISTICA S.L.", "LEVANTE SERVICIOS SA"}))
+
+			absorbida := borme.Anuncios[60002]
+			fusion = absorbida.Actos[0].(*models.BormeActoReestructuracion)
+			gomega.Expect(fusion.Empresas(models.RolAbsorbida)).To(gomega.Equal([]string{"LEVANTE LOGISTICA SL"}))
+			gomega.Expect(absorbida.EmpresasRelacionadas()).To(gomega.Equal([]string{"GRUPO LEVANTE INVERSIONES SL"}))
+
+			escision := borme.Anuncios[60003].Actos[0].(*models.BormeActoReestructuracion)
+			gomega.Expect(escision.Tipo).To(gomega.Equal(models.TipoEscisionParcial))
+			gomega.Expect(escision.Empresas(models.RolBeneficiaria)).To(gomega.Equal([]string{"MARTINEZ PATRIMONIAL SL", "MARTINEZ ENERGIA SL"}))
+			gomega.Expect(escision.Empresas(models.RolEscindida)).To(gomega.Equal([]string{"INDUSTRIAS MARTINEZ SA"}))
+
+			cesion := borme.Anuncios[60004].Actos[0].(*models.BormeActoReestructuracion)
+			gomega.Expect(cesion.Tipo).To(gomega.Equal(models.TipoCesionGlobal))
+			gomega.Expect(cesion.Empresas(models.RolCedente)).To(gomega.Equal([]string{"COMERCIAL NORTE SL"}))
+			gomega.Expect(cesion.Empresas(models.RolCesionaria)).To(gomega.Equal([]string{"DISTRIBUCIONES SUR SL"}))
+		})
+	})
+
+	ginkgo.Describe("Cambios de denominación", func() {
+		ginkgo.It("should parse the previous and new names", func() {
+			borme, err := pypdf2.NewParser("testdata/denominaciones.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			cambio := borme.Anuncios[61001].Actos[0].(*models.BormeActoDenominacion)
+			gomega.Expect(cambio.Anterior).To(gomega.Equal("PANADERIA LA ESPIGA SL"))
+			gomega.Expect(cambio.Nueva).To(gomega.Equal("ESPIGA ALIMENTACION SL"))
+			gomega.Expect(cambio.GetValue()).To(gomega.Equal("ESPIGA ALIMENTACION SL"))
+
+			cambio = borme.Anuncios[61002].Actos[0].(*models.BormeActoDenominacion)
+			gomega.Expect(cambio.Nueva).To(gomega.Equal("ATLANTICO DIGITAL SA"))
+			gomega.Expect(cambio.NuevaNormalizada).To(gomega.Equal("ATLANTICO DIGITAL SA"))
+		})
+
+		ginkgo.It("should resolve name histories across bulletins", func() {
+			bulletin := func(date time.Time, id int, anterior, nueva string) *models.Borme {
+				b := models.NewBorme(date, models.SeccionA, nil, 1)
+				b.Anuncios[id] = &models.BormeAnuncio{
+					ID:      id,
+					Empresa: anterior,
+					Actos:   []models.BormeActo{actos.ParseDenominacion(actos.ActoCambioDenominacion, nueva+".", anterior)},
+				}
+				return b
+			}
+			denominaciones := actos.NewDenominaciones(
+				bulletin(time.Date(2019, time.March, 4, 0, 0, 0, 0, time.UTC), 30, "ESPIGA ALIMENTACION SL", "GRUPO ESPIGA, S.L."),
+				bulletin(time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC), 10, "PANADERIA LA ESPIGA SL", "ESPIGA ALIMENTACION SL"),
+				bulletin(time.Date(2016, time.June, 1, 0, 0, 0, 0, time.UTC), 20, "TALLERES NORTE SL", "NORTE MOTOR SL"),
+			)
+
+			for _, name := range []string{"PANADERIA LA ESPIGA SL", "ESPIGA ALIMENTACION SL", "GRUPO ESPIGA SL"} {
+				gomega.Expect(denominaciones.Nombres(name)).To(gomega.Equal([]string{
+					"PANADERIA LA ESPIGA SL", "ESPIGA ALIMENTACION SL", "GRUPO ESPIGA, S.L.",
+				}))
+				gomega.Expect(denominaciones.Actual(name)).To(gomega.Equal("GRUPO ESPIGA, S.L."))
+			}
+			gomega.Expect(denominaciones.Historial("GRUPO ESPIGA SL")).To(gomega.HaveLen(2))
+			gomega.Expect(denominaciones.Historial("GRUPO ESPIGA SL")[0].AnuncioID).To(gomega.Equal(10))
+			gomega.Expect(denominaciones.Same("PANADERIA LA ESPIGA SL", "GRUPO ESPIGA SL")).To(gomega.BeTrue())
+			gomega.Expect(denominaciones.Same("PANADERIA LA ESPIGA SL", "NORTE MOTOR SL")).To(gomega.BeFalse())
+			gomega.Expect(denominaciones.Nombres("DESCONOCIDA SA")).To(gomega.BeNil())
+
+			// Names as published lead to the same company
+			actual := denominaciones.Actual("ESPIGA ALIMENTACION, S.L.")
+			gomega.Expect(actual).To(gomega.Equal("GRUPO ESPIGA, S.L."))
+			gomega.Expect(denominaciones.Nombres(actual)).To(gomega.HaveLen(3))
+			gomega.Expect(denominaciones.Historial(actual)).To(gomega.HaveLen(2))
+			gomega.Expect(denominaciones.Same(actual, "Panaderia la Espiga S.L.")).To(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Describe("Actos concursales", func() {
+		ginkgo.It("should extract court, procedure, phase and administradores", func() {
+			borme, err := pypdf2.NewParser("testdata/concursales.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.Diagnostics.Filter(models.SeverityWarning)).To(gomega.HaveLen(1)) // no bulletin header
+
+			concurso := borme.Anuncios[62001].Actos[0].(*models.BormeActoConcursal)
+			gomega.Expect(concurso.Procedimiento).To(gomega.Equal("123/2015"))
+			gomega.Expect(concurso.FechaResolucion).To(gomega.Equal(time.Date(2015, time.March, 12, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(concurso.Juzgado).To(gomega.Equal("num. 1 de Madrid"))
+			gomega.Expect(concurso.Juez).To(gomega.Equal("FERNANDEZ RUIZ ANA"))
+			gomega.Expect(concurso.Fase).To(gomega.Equal(models.FaseDeclaracion))
+			gomega.Expect(concurso.Administradores).To(gomega.Equal([]string{"GARCIA LOPEZ MARIA", "AUDITORES CONCURSALES SLP"}))
+
+			liquidacion := borme.Anuncios[62003].Actos[0].(*models.BormeActoConcursal)
+			gomega.Expect(liquidacion.Fase).To(gomega.Equal(models.FaseLiquidacion))
+			gomega.Expect(liquidacion.Procedimiento).To(gomega.Equal("45/2014"))
+			gomega.Expect(liquidacion.Juzgado).To(gomega.Equal("de lo Mercantil num. 2 de Toledo"))
+			gomega.Expect(liquidacion.FechaResolucion.IsZero()).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should detect the latest phase mentioned", func() {
+			gomega.Expect(actos.ParseConcursal("Resoluciones judiciales", "Aprobación del convenio.").Fase).To(gomega.Equal(models.FaseConvenio))
+			gomega.Expect(actos.ParseConcursal("Situación concursal", "Auto de conclusión del concurso.").Fase).To(gomega.Equal(models.FaseConclusion))
+		})
+
+		ginkgo.It("should keep only insolvency anuncios with the concursal filter", func() {
+			result, err := parser.ParseWith("testdata/concursales.txt", models.SeccionA, parser.Options{Filter: models.IsConcursal})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			borme := result.(*models.Borme)
+			gomega.Expect(borme.Anuncios).To(gomega.HaveLen(2))
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(62001))
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(62003))
+			gomega.Expect(borme.AnunciosRango).To(gomega.Equal([2]int{62001, 62003}))
+		})
+	})
+
+	ginkgo.Describe("Fe de erratas", func() {
+		ginkgo.It("should extract the referenced bulletin and anuncio", func() {
+			errata := actos.ParseFeDeErratas(actos.ActoFeDeErratas,
+				`Advertida errata en el anuncio 57344 publicado en el BORME núm. 101, de fecha jueves 28 de mayo de 2015, donde dice "ACME SL", debe decir "ACME SA".`)
+			gomega.Expect(errata.Referencia.AnuncioID).To(gomega.Equal(57344))
+			gomega.Expect(errata.Referencia.Num).To(gomega.Equal(101))
+			gomega.Expect(errata.Referencia.Date).To(gomega.Equal(time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(errata.Dice).To(gomega.Equal("ACME SL"))
+			gomega.Expect(errata.DebeDecir).To(gomega.Equal("ACME SA"))
+
+			errata = actos.ParseFeDeErratas(actos.ActoFeDeErratas, "Anuncio 1200, cve: BORME-A-2014-20-08.")
+			gomega.Expect(errata.Referencia.CVE.String()).To(gomega.Equal("BORME-A-2014-20-08"))
+			gomega.Expect(errata.Referencia.Num).To(gomega.Equal(20))
+			gomega.Expect(errata.Referencia.Year()).To(gomega.Equal(2014))
+			gomega.Expect(errata.Referencia.AnuncioID).To(gomega.Equal(1200))
+		})
+
+		ginkgo.It("should apply corrections to the original anuncio", func() {
+			original, err := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			erratas, err := pypdf2.NewParser("testdata/BORME-A-2015-105-28.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(erratas.Diagnostics.Filter(models.SeverityWarning)).To(gomega.BeEmpty())
+
+			pendientes := actos.ApplyErratas(original, erratas)
+			gomega.Expect(pendientes).To(gomega.HaveLen(1))
+			gomega.Expect(pendientes[0].Referencia.AnuncioID).To(gomega.Equal(41000))
+
+			anuncio := original.Anuncios[57344]
+			gomega.Expect(anuncio.Correcciones).To(gomega.HaveLen(1))
+			gomega.Expect(anuncio.Correcciones[0].AnuncioID).To(gomega.Equal(59120))
+			gomega.Expect(anuncio.Correcciones[0].Num).To(gomega.Equal(105))
+			gomega.Expect(anuncio.Correcciones[0].Aplicada).To(gomega.BeTrue())
+
+			nombramientos := anuncio.Actos[0].(*models.BormeActoCargo)
+			gomega.Expect(nombramientos.Value[0].HolderNames()).To(gomega.Equal([]string{
+				"RAMA SANCHEZ JOSE PEDRO", "RAMA SANCHEZ JAVIER LUIS",
+			}))
+
+			holder := nombramientos.Value[0].Holders[1]
+			gomega.Expect(holder.Kind).To(gomega.Equal(models.EntityPersona))
+			gomega.Expect(holder.Persona).To(gomega.Equal(nombres.Parse("RAMA SANCHEZ JAVIER LUIS")))
+		})
+
+		ginkgo.It("should apply corrections once", func() {
+			original, _ := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+			erratas, _ := pypdf2.NewParser("testdata/BORME-A-2015-105-28.txt").Parse()
+			actos.ApplyErratas(original, erratas)
+			actos.ApplyErratas(original, erratas)
+
+			anuncio := original.Anuncios[57344]
+			gomega.Expect(anuncio.Correcciones).To(gomega.HaveLen(1))
+			gomega.Expect(anuncio.Correcciones[0].Aplicada).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should recompute the company name derived values", func() {
+			borme := models.NewBorme(time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC), models.SeccionA, nil, 101)
+			borme.AddAnuncio(&models.BormeAnuncio{ID: 1, Empresa: "ACME SL", EmpresaNormalizada: "ACME SL"})
+			borme.AddAnuncio(&models.BormeAnuncio{ID: 2, Empresa: "BETA SL", Actos: []models.BormeActo{
+				actos.ParseFeDeErratas(actos.ActoFeDeErratas,
+					`Advertida errata en el anuncio 1, donde dice "ACME SL", debe decir "ACME, S.A. EN LIQUIDACION".`),
+			}})
+			actos.ApplyErratas(borme)
+
+			anuncio := borme.Anuncios[1]
+			gomega.Expect(anuncio.Empresa).To(gomega.Equal("ACME, S.A. EN LIQUIDACION"))
+			gomega.Expect(anuncio.EmpresaNormalizada).To(gomega.Equal("ACME SA"))
+			gomega.Expect(anuncio.Liquidacion).To(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Describe("Constitución", func() {
+		ginkgo.It("should expose each field of the acto", func() {
+			acto := actos.ParseConstitucion(actos.ActoConstitucion,
+				"Comienzo de operaciones: 12.01.2016. Objeto social: Restaurante y cafetería. "+
+					"Domicilio: AVDA DE LA PAZ 4 (ALCALA DE HENARES). Capital: 60.000,00 Euros. "+
+					"Adm. Solid.: VEGA ORTIZ LUIS;VEGA ORTIZ ANA.")
+			gomega.Expect(acto.ComienzoOperaciones).To(gomega.Equal(time.Date(2016, time.January, 12, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(acto.ObjetoSocial.Texto).To(gomega.Equal("Restaurante y cafetería"))
+			gomega.Expect(acto.Domicilio.Direccion).To(gomega.Equal("AVDA DE LA PAZ 4"))
+			gomega.Expect(acto.Domicilio.Municipio).To(gomega.Equal("ALCALA DE HENARES"))
+			gomega.Expect(acto.Capital.Importe).To(gomega.Equal(60000.0))
+			gomega.Expect(acto.Capital.Moneda).To(gomega.Equal("Euros"))
+			gomega.Expect(acto.Cargos).To(gomega.HaveLen(1))
+			gomega.Expect(acto.Cargos[0].HolderNames()).To(gomega.Equal([]string{"VEGA ORTIZ LUIS", "VEGA ORTIZ ANA"}))
+		})
+
+		ginkgo.It("should take the initial board from the following Nombramientos", func() {
+			borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			constitucion := borme.Anuncios[58001].Constitucion()
+			gomega.Expect(constitucion).ToNot(gomega.BeNil())
+			gomega.Expect(constitucion.ComienzoOperaciones).To(gomega.Equal(time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(constitucion.Domicilio.Direccion).To(gomega.Equal("C/ MAYOR 1"))
+			gomega.Expect(constitucion.Domicilio.Municipio).To(gomega.Equal("BARCELONA"))
+			gomega.Expect(constitucion.Capital.Importe).To(gomega.Equal(3000.0))
+			gomega.Expect(constitucion.Capital.Texto).To(gomega.Equal("3.000,00 Euros"))
+			gomega.Expect(constitucion.Cargos).To(gomega.HaveLen(1))
+			gomega.Expect(constitucion.Cargos[0].Name).To(gomega.Equal("Adm. Solid."))
+			gomega.Expect(constitucion.Cargos[0].HolderNames()).To(gomega.Equal([]string{"PUIG FERRER JORDI", "PUIG FERRER MONTSERRAT"}))
+
+			gomega.Expect(borme.Anuncios[58002].Constitucion()).To(gomega.BeNil())
+		})
+	})
+})
@@ -11,7 +11,7 @@ import (
 var _ = ginkgo.Describe("Borme Model", func() {
 	var borme *models.Borme
 	testDate := time.Date(2015, 10, 27, 0, 0, 0, 0, time.UTC)
-	madrid := &models.Provincia{Code: 28, Name: "Madrid"}
+	madrid := &models.Provincia{Code: 280, Name: "Madrid", INE: 28}
 
 	ginkgo.BeforeEach(func() {
 		borme = models.NewBorme(testDate, models.SeccionA, madrid, 273)
@@ -64,11 +64,40 @@ var _ = ginkgo.Describe("Borme Model", func() {
 		})
 	})
 
+	ginkgo.Describe("FindRangosSolapados", func() {
+		ginkgo.It("should report overlapping bulletins of the same year", func() {
+			madrid := models.NewBorme(testDate, models.SeccionA, nil, 273)
+			madrid.SetAnunciosRango(57344, 57400)
+			barcelona := models.NewBorme(testDate, models.SeccionA, nil, 273)
+			barcelona.SetAnunciosRango(57390, 57450)
+			sevilla := models.NewBorme(testDate, models.SeccionA, nil, 273)
+			sevilla.SetAnunciosRango(57451, 57500)
+
+			solapados := models.FindRangosSolapados([]*models.Borme{sevilla, barcelona, madrid})
+			gomega.Expect(solapados).To(gomega.HaveLen(1))
+			gomega.Expect(solapados[0].A).To(gomega.Equal(madrid))
+			gomega.Expect(solapados[0].B).To(gomega.Equal(barcelona))
+		})
+
+		ginkgo.It("should ignore bulletins from different years", func() {
+			b2015 := models.NewBorme(testDate, models.SeccionA, nil, 273)
+			b2015.SetAnunciosRango(100, 200)
+			b2016 := models.NewBorme(testDate.AddDate(1, 0, 0), models.SeccionA, nil, 10)
+			b2016.SetAnunciosRango(150, 250)
+			gomega.Expect(models.FindRangosSolapados([]*models.Borme{b2015, b2016})).To(gomega.BeEmpty())
+		})
+	})
+
 	ginkgo.Describe("SetCVE", func() {
 		ginkgo.It("should set CVE code", func() {
 			cve := "BORME-A-2015-273-28"
-			borme.SetCVE(cve)
-			gomega.Expect(borme.CVE).To(gomega.Equal(cve))
+			gomega.Expect(borme.SetCVE(cve)).To(gomega.Succeed())
+			gomega.Expect(borme.CVE.String()).To(gomega.Equal(cve))
+		})
+
+		ginkgo.It("should reject an invalid CVE", func() {
+			gomega.Expect(borme.SetCVE("BORME-A-2015-273")).ToNot(gomega.Succeed())
+			gomega.Expect(borme.CVE.IsZero()).To(gomega.BeTrue())
 		})
 	})
 
@@ -128,4 +157,27 @@ var _ = ginkgo.Describe("Provincia", func() {
 			gomega.Expect(provincia).To(gomega.BeNil())
 		})
 	})
+
+	ginkgo.Describe("ProvinciaFromINE", func() {
+		ginkgo.It("should return the catalogue entry", func() {
+			gomega.Expect(models.ProvinciaFromINE(28)).To(gomega.Equal(&models.Provincia{Code: 280, Name: "Madrid", INE: 28}))
+			gomega.Expect(models.ProvinciaFromINE(38).Name).To(gomega.Equal("Santa Cruz de Tenerife"))
+		})
+
+		ginkgo.It("should cover every INE province", func() {
+			for code := 1; code <= 52; code++ {
+				gomega.Expect(models.ProvinciaFromINE(code)).ToNot(gomega.BeNil(), "INE code %d", code)
+			}
+			gomega.Expect(models.ProvinciaFromINE(53)).To(gomega.BeNil())
+		})
+	})
+
+	ginkgo.Describe("ProvinciaFromName", func() {
+		ginkgo.It("should match published names ignoring case and accents", func() {
+			gomega.Expect(models.ProvinciaFromName("MÁLAGA").INE).To(gomega.Equal(29))
+			gomega.Expect(models.ProvinciaFromName("ARABA/ÁLAVA").INE).To(gomega.Equal(1))
+			gomega.Expect(models.ProvinciaFromName("A CORUÑA").Code).To(gomega.Equal(150))
+			gomega.Expect(models.ProvinciaFromName("Atlantis")).To(gomega.BeNil())
+		})
+	})
 })
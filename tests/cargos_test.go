@@ -0,0 +1,134 @@
+package gormeparser_test
+
+import (
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser/cargos"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Cargos Parser", func() {
+	ginkgo.Describe("Parse", func() {
+		ginkgo.It("should parse several holders of one cargo", func() {
+			result := cargos.Parse("Adm. Solid.: RAMA SANCHEZ JOSE PEDRO;RAMA SANCHEZ JAVIER.")
+			gomega.Expect(result).To(gomega.HaveLen(1))
+			gomega.Expect(result[0].Name).To(gomega.Equal("Adm. Solid."))
+			gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"RAMA SANCHEZ JOSE PEDRO", "RAMA SANCHEZ JAVIER"}))
+		})
+
+		ginkgo.It("should keep cargos in order of appearance", func() {
+			result := cargos.Parse("Cons.Del.Man: FERNANDEZ RUIZ ANTONIO;MARTIN GIL LUIS. " +
+				"Consejero: FERNANDEZ RUIZ ANTONIO;MARTIN GIL LUIS;INVERSIONES ALFA 2005 SL. " +
+				"Presidente: FERNANDEZ RUIZ ANTONIO. Secretario: MARTIN GIL LUIS.")
+			names := make([]string, 0, len(result))
+			for _, c := range result {
+				names = append(names, c.Name)
+			}
+			gomega.Expect(names).To(gomega.Equal([]string{"Cons.Del.Man", "Consejero", "Presidente", "Secretario"}))
+			gomega.Expect(result[1].HolderNames()).To(gomega.ContainElement("INVERSIONES ALFA 2005 SL"))
+		})
+
+		ginkgo.It("should handle accented names", func() {
+			result := cargos.Parse("Adm. Unico: GARCÍA LÓPEZ MARÍA ÁNGELES. Apoderado: MUÑOZ PEÑA JOSÉ.")
+			gomega.Expect(result).To(gomega.HaveLen(2))
+			gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"GARCÍA LÓPEZ MARÍA ÁNGELES"}))
+			gomega.Expect(result[1].HolderNames()).To(gomega.Equal([]string{"MUÑOZ PEÑA JOSÉ"}))
+		})
+
+		ginkgo.It("should prefer the longest abbreviation", func() {
+			result := cargos.Parse("Liquidador M.: PEREZ DIAZ ANA;GOMEZ SANZ PEDRO.")
+			gomega.Expect(result).To(gomega.HaveLen(1))
+			gomega.Expect(result[0].Name).To(gomega.Equal("Liquidador M."))
+			gomega.Expect(result[0].Holders).To(gomega.HaveLen(2))
+		})
+
+		ginkgo.It("should keep dots that belong to company names", func() {
+			result := cargos.Parse("Auditor: ERNST & YOUNG S.L.")
+			gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"ERNST & YOUNG S.L."}))
+
+			result = cargos.Parse("Auditor: ACME S. L.")
+			gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"ACME S. L."}))
+		})
+
+		ginkgo.It("should merge repeated cargos", func() {
+			result := cargos.Parse("Apoderado: LOPEZ VIDAL MARTA. Apoderado: SANZ ORTEGA RAUL.")
+			gomega.Expect(result).To(gomega.HaveLen(1))
+			gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"LOPEZ VIDAL MARTA", "SANZ ORTEGA RAUL"}))
+		})
+
+		ginkgo.It("should accept unlisted cargos at the start", func() {
+			result := cargos.Parse("Mbro.Comité: RUIZ PARDO ELENA.")
+			gomega.Expect(result).To(gomega.HaveLen(1))
+			gomega.Expect(result[0].Name).To(gomega.Equal("Mbro.Comité"))
+		})
+
+		ginkgo.It("should classify holders as persons or companies", func() {
+			result := cargos.Parse("Consejero: FERNANDEZ RUIZ ANTONIO;INVERSIONES ALFA 2005 SL;BETA HOLDING GMBH.")
+			holders := result[0].Holders
+			gomega.Expect(holders[0].Kind).To(gomega.Equal(models.EntityPersona))
+			gomega.Expect(holders[0].FormaJuridica).To(gomega.BeEmpty())
+			gomega.Expect(holders[1].Kind).To(gomega.Equal(models.EntityEmpresa))
+			gomega.Expect(holders[1].FormaJuridica).To(gomega.Equal(models.FormaJuridicaSL))
+			gomega.Expect(holders[2].FormaJuridica).To(gomega.Equal(models.FormaJuridicaGMBH))
+		})
+
+		ginkgo.It("should attach the representative to a company administrator", func() {
+			result := cargos.Parse("Adm. Unico: GESTIONES NORTE 2010 SL. Representante: MARTIN GIL LUIS. Apoderado: SANZ ORTEGA RAUL.")
+			gomega.Expect(result).To(gomega.HaveLen(2))
+			gomega.Expect(result[0].Holders[0].Representante).To(gomega.Equal("MARTIN GIL LUIS"))
+			gomega.Expect(result[1].Name).To(gomega.Equal("Apoderado"))
+		})
+
+		ginkgo.It("should keep Representante as a cargo after a person", func() {
+			result := cargos.Parse("Apoderado: SANZ ORTEGA RAUL. Representante: MARTIN GIL LUIS.")
+			gomega.Expect(result).To(gomega.HaveLen(2))
+			gomega.Expect(result[0].Holders[0].Representante).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should return nil for text without cargos", func() {
+			gomega.Expect(cargos.Parse("RAMA SANCHEZ JOSE PEDRO")).To(gomega.BeNil())
+		})
+	})
+})
+
+var _ = ginkgo.Describe("Cargo Catalogue", func() {
+	ginkgo.It("should map every abbreviation to a catalogue entry", func() {
+		for abbr, id := range models.CargoAbreviaturas {
+			info, ok := models.Cargos[id]
+			gomega.Expect(ok).To(gomega.BeTrue(), abbr)
+			gomega.Expect(info.ID).To(gomega.Equal(id))
+			gomega.Expect(info.Nombre).ToNot(gomega.BeEmpty())
+			gomega.Expect(info.NameEN).ToNot(gomega.BeEmpty())
+			gomega.Expect(info.Categoria).ToNot(gomega.BeEmpty())
+		}
+	})
+
+	ginkgo.It("should look up abbreviations case-insensitively", func() {
+		info, ok := models.LookupCargo("adm. unico")
+		gomega.Expect(ok).To(gomega.BeTrue())
+		gomega.Expect(info.ID).To(gomega.Equal("administrador_unico"))
+		gomega.Expect(info.Categoria).To(gomega.Equal(models.CargoCategoriaAdministracion))
+	})
+
+	ginkgo.It("should not find unknown abbreviations", func() {
+		_, ok := models.LookupCargo("Mbro.Comité")
+		gomega.Expect(ok).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should attach the canonical cargo to parsed cargos", func() {
+		result := cargos.Parse("Con.Delegado: FERNANDEZ RUIZ ANTONIO. Apo.Manc.: MARTIN GIL LUIS.")
+		gomega.Expect(result[0].Canonical).ToNot(gomega.BeNil())
+		gomega.Expect(result[0].Canonical.Nombre).To(gomega.Equal("Consejero delegado"))
+		gomega.Expect(result[1].Categoria()).To(gomega.Equal(models.CargoCategoriaApoderamiento))
+	})
+
+	ginkgo.It("should filter an acto by category", func() {
+		acto := &models.BormeActoCargo{
+			Name:  "Nombramientos",
+			Value: cargos.Parse("Adm. Solid.: RAMA SANCHEZ JAVIER. Auditor: AUDITORES ASOCIADOS SL. Apoderado: LOPEZ VIDAL MARTA."),
+		}
+		directors := acto.FilterCategoria(models.CargoCategoriaAdministracion)
+		gomega.Expect(directors).To(gomega.HaveLen(1))
+		gomega.Expect(directors[0].Name).To(gomega.Equal("Adm. Solid."))
+	})
+})
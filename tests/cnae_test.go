@@ -0,0 +1,58 @@
+package gormeparser_test
+
+import (
+	"github.com/argami/gormeparser/internal/cnae"
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser/actos"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("CNAE classification", func() {
+	ginkgo.It("should prefer the most specific rule", func() {
+		result := cnae.Classify("La administración de fincas.")
+		gomega.Expect(result).ToNot(gomega.BeEmpty())
+		gomega.Expect(result[0].Codigo).To(gomega.Equal("6832"))
+		gomega.Expect(result[0].Seccion).To(gomega.Equal("L"))
+		gomega.Expect(result[0].Confianza).To(gomega.BeNumerically(">", result[1].Confianza))
+	})
+
+	ginkgo.It("should score several activities", func() {
+		result := cnae.Classify("Restaurante, bar y cafetería. Organización de espectáculos.")
+		gomega.Expect(result).To(gomega.HaveLen(2))
+		gomega.Expect(result[0].Codigo).To(gomega.Equal("56"))
+		gomega.Expect(result[0].Confianza).To(gomega.Equal(0.75))
+		gomega.Expect(result[1].Codigo).To(gomega.Equal("90"))
+		gomega.Expect(result[1].Confianza).To(gomega.Equal(0.13))
+	})
+
+	ginkgo.It("should match whole words only", func() {
+		gomega.Expect(cnae.Classify("Barnizado de puertas")).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should map codes to CNAE sections", func() {
+		gomega.Expect(cnae.Seccion("01")).To(gomega.Equal("A"))
+		gomega.Expect(cnae.Seccion("62")).To(gomega.Equal("J"))
+		gomega.Expect(cnae.Seccion("6832")).To(gomega.Equal("L"))
+		gomega.Expect(cnae.Seccion("04")).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should classify the objeto social of Constitución actos", func() {
+		borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.txt").Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		constitucion := borme.Anuncios[58001].Actos[0].(*models.BormeActoConstitucion)
+		gomega.Expect(constitucion.ObjetoSocial.Texto).To(gomega.Equal("La administración de fincas"))
+		gomega.Expect(constitucion.ObjetoSocial.Principal().Codigo).To(gomega.Equal("6832"))
+
+		gomega.Expect(cnae.ConstitucionesPorSeccion(borme)).To(gomega.Equal(map[string]int{"L": 1}))
+	})
+
+	ginkgo.It("should classify changes of objeto social", func() {
+		acto := actos.ParseObjetoSocial(actos.ActoCambioObjetoSocial, "Objeto social: Desarrollo de aplicaciones informáticas y software.")
+		gomega.Expect(acto.ObjetoSocial.Texto).To(gomega.Equal("Desarrollo de aplicaciones informáticas y software"))
+		gomega.Expect(acto.ObjetoSocial.Principal().Codigo).To(gomega.Equal("62"))
+		gomega.Expect(acto.ObjetoSocial.Principal().Confianza).To(gomega.Equal(1.0))
+	})
+})
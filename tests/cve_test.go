@@ -0,0 +1,59 @@
+package gormeparser_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("CVE", func() {
+	ginkgo.It("should parse a Section A CVE", func() {
+		cve, err := models.ParseCVE("BORME-A-2015-101-28")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(cve).To(gomega.Equal(models.CVE{Seccion: models.SeccionA, Year: 2015, NBO: 101, Provincia: 28}))
+		gomega.Expect(cve.String()).To(gomega.Equal("BORME-A-2015-101-28"))
+	})
+
+	ginkgo.It("should keep two-digit province codes", func() {
+		cve, err := models.ParseCVE("BORME-B-2015-101-08")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(cve.Provincia).To(gomega.Equal(8))
+		gomega.Expect(cve.String()).To(gomega.Equal("BORME-B-2015-101-08"))
+	})
+
+	ginkgo.It("should parse a Section C CVE", func() {
+		cve, err := models.ParseCVE("BORME-C-2011-20488")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(cve).To(gomega.Equal(models.CVE{Seccion: models.SeccionC, Year: 2011, Anuncio: 20488}))
+		gomega.Expect(cve.URLHTML()).To(gomega.Equal("https://www.boe.es/diario_borme/txt.php?id=BORME-C-2011-20488"))
+		gomega.Expect(cve.Filename(".xml")).To(gomega.Equal("BORME-C-2011-20488.xml"))
+	})
+
+	ginkgo.It("should reject invalid CVEs", func() {
+		for _, s := range []string{"", "BORME-A-2015-101", "BORME-C-2011-20488-28", "BORME-A-2015-101-99", "BORME-D-2015-101-28", "BORME-A-2015-0-28"} {
+			_, err := models.ParseCVE(s)
+			gomega.Expect(err).To(gomega.HaveOccurred(), s)
+		}
+	})
+
+	ginkgo.It("should marshal to a JSON string", func() {
+		borme := models.NewBorme(time.Date(2015, 5, 28, 0, 0, 0, 0, time.UTC), models.SeccionA, nil, 101)
+		gomega.Expect(borme.SetCVE("BORME-A-2015-101-28")).To(gomega.Succeed())
+		data, err := json.Marshal(borme)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(string(data)).To(gomega.ContainSubstring(`"cve":"BORME-A-2015-101-28"`))
+
+		decoded, err := models.BormeFromJSON(data)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(decoded.CVE).To(gomega.Equal(borme.CVE))
+	})
+
+	ginkgo.It("should omit an unset CVE", func() {
+		data, err := json.Marshal(models.NewBorme(time.Date(2015, 5, 28, 0, 0, 0, 0, time.UTC), models.SeccionA, nil, 101))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(string(data)).ToNot(gomega.ContainSubstring(`"cve"`))
+	})
+})
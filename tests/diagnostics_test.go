@@ -0,0 +1,62 @@
+package gormeparser_test
+
+import (
+	"errors"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Parse Diagnostics", func() {
+	ginkgo.It("should report nothing for a clean bulletin", func() {
+		borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.txt", pypdf2.WithStrict(true)).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Diagnostics).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should locate unrecognised actos and cargos by page and anuncio", func() {
+		borme, err := pypdf2.NewParser("testdata/actos_desconocidos.txt").Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Diagnostics).To(gomega.ConsistOf(
+			models.Diagnostic{Severity: models.SeverityInfo, Page: 1, AnuncioID: 57400, Message: "unlisted cargo", Snippet: "Vocal Suplente"},
+			models.Diagnostic{Severity: models.SeverityWarning, Page: 2, AnuncioID: 57401, Message: "unrecognised acto", Snippet: "Inscripción singular"},
+			models.Diagnostic{Severity: models.SeverityWarning, Page: 2, AnuncioID: 57401, Message: "no cargos found in Nombramientos", Snippet: "sin cargos."},
+		))
+		gomega.Expect(borme.Diagnostics.HasErrors()).To(gomega.BeFalse())
+		gomega.Expect(borme.Diagnostics.Filter(models.SeverityWarning)).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("should report duplicated anuncios and a missing header", func() {
+		borme, _ := pypdf2.NewParser("testdata/anuncios_duplicados.txt").Parse()
+		gomega.Expect(borme.Diagnostics).To(gomega.HaveLen(2))
+		gomega.Expect(borme.Diagnostics[0].Message).To(gomega.Equal("bulletin header not found"))
+		gomega.Expect(borme.Diagnostics[1].AnuncioID).To(gomega.Equal(57344))
+		gomega.Expect(borme.Diagnostics[1].Snippet).To(gomega.Equal("57344 - TRANSPORTES VEGA SL."))
+	})
+
+	ginkgo.It("should distinguish a failed extraction from an empty bulletin", func() {
+		borme, err := pypdf2.NewParser("testdata/missing.pdf").Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Anuncios).To(gomega.BeEmpty())
+		gomega.Expect(borme.Diagnostics.HasErrors()).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should turn diagnostics into an error in strict mode", func() {
+		_, err := pypdf2.NewParser("testdata/actos_desconocidos.txt", pypdf2.WithStrict(true)).Parse()
+		var diagErr *models.DiagnosticsError
+		gomega.Expect(errors.As(err, &diagErr)).To(gomega.BeTrue())
+		gomega.Expect(diagErr.Diagnostics).To(gomega.HaveLen(2))
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("2 diagnostics"))
+	})
+
+	ginkgo.It("should pass strict mode through the router", func() {
+		_, err := parser.ParseWith("testdata/missing.pdf", models.SeccionA, parser.Options{Strict: true})
+		gomega.Expect(err).To(gomega.HaveOccurred())
+
+		_, err = parser.ParseWith("testdata/anuncios_duplicados.txt", models.SeccionA, parser.Options{})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/argami/gormeparser/internal/download"
+	"github.com/argami/gormeparser/internal/models"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 )
@@ -42,6 +43,98 @@ var _ = ginkgo.Describe("Download", func() {
 			gomega.Expect(urlStr).To(gomega.ContainSubstring("BORME-S-2015"))
 			gomega.Expect(urlStr).To(gomega.ContainSubstring("xml.php"))
 		})
+
+		ginkgo.It("should pad the month and day", func() {
+			date := time.Date(2015, 5, 8, 0, 0, 0, 0, time.UTC)
+			gomega.Expect(download.GetURLXML(date)).To(gomega.Equal("https://www.boe.es/diario_borme/xml.php?id=BORME-S-20150508"))
+		})
+	})
+
+	ginkgo.Describe("ParseSumario", func() {
+		sumario := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<sumario>
+  <meta><pub>BORME</pub><fecha>28/05/2015</fecha></meta>
+  <diario nbo="101">
+    <seccion num="A" nombre="SECCIÓN PRIMERA. Empresarios. Actos inscritos">
+      <emisor nombre="Actos inscritos">
+        <item id="BORME-A-2015-101-08">
+          <titulo>BARCELONA</titulo>
+          <urlPdf>/borme/dias/2015/05/28/pdfs/BORME-A-2015-101-08.pdf</urlPdf>
+        </item>
+        <item id="BORME-A-2015-101-28">
+          <titulo>MADRID</titulo>
+          <urlPdf>/borme/dias/2015/05/28/pdfs/BORME-A-2015-101-28.pdf</urlPdf>
+        </item>
+      </emisor>
+    </seccion>
+    <seccion num="C" nombre="SECCIÓN SEGUNDA. Anuncios y avisos legales">
+      <emisor nombre="CONVOCATORIAS DE JUNTAS">
+        <item id="BORME-C-2015-6112">
+          <titulo>ACME, S.A.</titulo>
+          <urlPdf>/borme/dias/2015/05/28/pdfs/BORME-C-2015-6112.pdf</urlPdf>
+        </item>
+      </emisor>
+    </seccion>
+  </diario>
+</sumario>`)
+
+		ginkgo.It("should list the CVEs of a section", func() {
+			index, err := download.ParseSumario(sumario)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(index.Diario.NBO).To(gomega.Equal(101))
+
+			cves, err := index.CVEs(models.SeccionA)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cves).To(gomega.Equal([]models.CVE{
+				{Seccion: models.SeccionA, Year: 2015, NBO: 101, Provincia: 8},
+				{Seccion: models.SeccionA, Year: 2015, NBO: 101, Provincia: 28},
+			}))
+
+			cves, err = index.CVEs(models.SeccionC)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cves).To(gomega.Equal([]models.CVE{{Seccion: models.SeccionC, Year: 2015, Anuncio: 6112}}))
+		})
+
+		ginkgo.It("should read the bulletin number and PDF URLs", func() {
+			nbo, err := download.GetNBOFromXML(sumario)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(nbo).To(gomega.Equal(101))
+
+			urls, err := download.ParseXMLIndex(sumario)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(urls).To(gomega.HaveLen(3))
+			gomega.Expect(urls[1]).To(gomega.Equal("https://www.boe.es/borme/dias/2015/05/28/pdfs/BORME-A-2015-101-28.pdf"))
+		})
+
+		ginkgo.It("should reject a document that is not a sumario", func() {
+			_, err := download.ParseSumario([]byte("<error>No hay datos</error>"))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+
+	ginkgo.Describe("GetURLCVE", func() {
+		ginkgo.It("should derive the URLs from the CVE", func() {
+			cve, _ := models.ParseCVE("BORME-A-2015-101-28")
+			date := time.Date(2015, 5, 28, 0, 0, 0, 0, time.UTC)
+			urlStr, err := download.GetURLCVE(cve, date, "pdf")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(urlStr).To(gomega.Equal("https://www.boe.es/borme/dias/2015/05/28/pdfs/BORME-A-2015-101-28.pdf"))
+
+			urlStr, err = download.GetURLCVE(cve, time.Time{}, "xml")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(urlStr).To(gomega.Equal("https://www.boe.es/diario_borme/xml.php?id=BORME-A-2015-101-28"))
+		})
+
+		ginkgo.It("should require the date for PDFs", func() {
+			cve, _ := models.ParseCVE("BORME-A-2015-101-28")
+			_, err := download.GetURLCVE(cve, time.Time{}, "pdf")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should reject an invalid CVE", func() {
+			_, err := download.GetURLCVE(models.CVE{}, time.Time{}, "xml")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
 	})
 
 	ginkgo.Describe("DownloadFile", func() {
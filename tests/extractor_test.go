@@ -0,0 +1,63 @@
+package gormeparser_test
+
+import (
+	"os"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// fixedExtractor returns the same text for any file
+type fixedExtractor string
+
+func (e fixedExtractor) Extract(filename string) (string, error) { return string(e), nil }
+
+var _ = ginkgo.Describe("Text extractors", func() {
+	ginkgo.It("should read the text file stored alongside the PDF", func() {
+		extractor := pypdf2.NewTextFileExtractor("")
+		gomega.Expect(extractor.Path("testdata/BORME-A-2015-102-08.pdf")).To(gomega.Equal("testdata/BORME-A-2015-102-08.txt"))
+
+		borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.pdf", pypdf2.WithExtractor(extractor)).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Diagnostics).To(gomega.BeEmpty())
+		gomega.Expect(borme.Num).To(gomega.Equal(102))
+		gomega.Expect(borme.Anuncios).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("should report a missing text file as an error diagnostic", func() {
+		extractor := pypdf2.NewTextFileExtractor(".layout")
+		borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.pdf", pypdf2.WithExtractor(extractor)).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Diagnostics.HasErrors()).To(gomega.BeTrue())
+		gomega.Expect(borme.Diagnostics[0].Message).To(gomega.ContainSubstring("BORME-A-2015-102-08.layout"))
+	})
+
+	ginkgo.It("should accept any TextExtractor through the parse options", func() {
+		text, err := os.ReadFile("testdata/BORME-A-2015-101-28.txt")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		result, err := parser.ParseWith("BORME-A-2015-101-28.pdf", models.SeccionA, parser.Options{
+			Extractor: fixedExtractor(text),
+		})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		borme := result.(*models.Borme)
+		gomega.Expect(borme.Num).To(gomega.Equal(101))
+		gomega.Expect(borme.Anuncios).ToNot(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should select extractors by name", func() {
+		extractor, err := pypdf2.ExtractorByName("text")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(extractor).To(gomega.BeAssignableToTypeOf(&pypdf2.TextFileExtractor{}))
+
+		extractor, err = pypdf2.ExtractorByName("")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(extractor).To(gomega.BeAssignableToTypeOf(&pypdf2.PDFTextExtractor{}))
+
+		_, err = pypdf2.ExtractorByName("ocr")
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+})
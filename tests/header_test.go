@@ -0,0 +1,126 @@
+package gormeparser_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser/header"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// bulletinText builds a two-page bulletin with the given running lines
+func bulletinText(numLine2, cve2 string) string {
+	return strings.Join([]string{
+		"BOLETÍN OFICIAL DEL REGISTRO MERCANTIL",
+		"Núm. 101 Jueves 28 de mayo de 2015 Pág. 6843",
+		"SECCIÓN PRIMERA",
+		"Empresarios",
+		"Actos inscritos",
+		"MADRID",
+		"57344 - ALDARA CATERING SL.",
+		"cve: BORME-A-2015-101-28",
+		"Verificable en http://www.boe.es",
+		"BOLETÍN OFICIAL DEL REGISTRO MERCANTIL",
+		numLine2,
+		"57345 - TALLERES OMEGA SA.",
+		"cve: " + cve2,
+		"Verificable en http://www.boe.es",
+	}, "\n")
+}
+
+var _ = ginkgo.Describe("Header Extractor", func() {
+	ginkgo.It("should read the first-page header", func() {
+		h, err := header.Extract(bulletinText("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844", "BORME-A-2015-101-28"))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(h.Num).To(gomega.Equal(101))
+		gomega.Expect(h.Date).To(gomega.Equal(time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC)))
+		gomega.Expect(h.Titulo).To(gomega.Equal("SECCIÓN PRIMERA"))
+		gomega.Expect(h.Seccion).To(gomega.Equal(models.SeccionA))
+		gomega.Expect(h.Provincia).To(gomega.Equal("MADRID"))
+		gomega.Expect(h.ProvinciaCode).To(gomega.Equal(28))
+		gomega.Expect(h.CVE).To(gomega.Equal("BORME-A-2015-101-28"))
+		gomega.Expect(h.Paginas).To(gomega.HaveLen(2))
+		gomega.Expect(h.Paginas[1].Pagina).To(gomega.Equal(6844))
+	})
+
+	ginkgo.It("should accept consistent headers and filenames", func() {
+		h, _ := header.Extract(bulletinText("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844", "BORME-A-2015-101-28"))
+		gomega.Expect(h.Validate("BORME-A-2015-101-28.pdf")).To(gomega.BeEmpty())
+		gomega.Expect(h.Validate("downloads/BORME-A-2015-05-28.pdf")).To(gomega.BeEmpty())
+		gomega.Expect(h.Validate("boletin.txt")).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should report pages that disagree with the first page", func() {
+		h, _ := header.Extract(bulletinText("Núm. 102 Viernes 29 de mayo de 2015 Pág. 6844", "BORME-A-2015-102-28"))
+		errs := h.Validate("")
+		gomega.Expect(errs).To(gomega.HaveLen(3))
+		for _, e := range errs {
+			gomega.Expect(e.Source).To(gomega.Equal(header.SourcePage))
+			gomega.Expect(e.Page).To(gomega.Equal(2))
+		}
+		gomega.Expect(errs[0].Field).To(gomega.Equal(header.FieldNum))
+		gomega.Expect(errs[0].Expected).To(gomega.Equal("101"))
+		gomega.Expect(errs[0].Found).To(gomega.Equal("102"))
+		gomega.Expect(errs[1].Field).To(gomega.Equal(header.FieldFecha))
+		gomega.Expect(errs[2].Field).To(gomega.Equal(header.FieldCVE))
+		gomega.Expect(errs[0].Error()).To(gomega.Equal(`num mismatch in page 2: found "102", expected "101"`))
+	})
+
+	ginkgo.It("should report a wrong weekday", func() {
+		text := strings.Replace(bulletinText("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844", "BORME-A-2015-101-28"),
+			"Núm. 101 Jueves", "Núm. 101 Miércoles", 1)
+		h, _ := header.Extract(text)
+		errs := h.Validate("")
+		gomega.Expect(errs).To(gomega.HaveLen(1))
+		gomega.Expect(errs[0].Field).To(gomega.Equal(header.FieldDiaSemana))
+		gomega.Expect(errs[0].Expected).To(gomega.Equal("jueves"))
+	})
+
+	ginkgo.It("should report a CVE that disagrees with the header", func() {
+		text := strings.ReplaceAll(bulletinText("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844", "BORME-B-2015-101-08"),
+			"cve: BORME-A-2015-101-28", "cve: BORME-B-2015-101-08")
+		h, _ := header.Extract(text)
+		errs := h.Validate("")
+		gomega.Expect(errs).To(gomega.HaveLen(2))
+		gomega.Expect(errs[0].Source).To(gomega.Equal(header.SourceCVE))
+		gomega.Expect(errs[0].Field).To(gomega.Equal(header.FieldSeccion))
+		gomega.Expect(errs[1].Field).To(gomega.Equal(header.FieldProvincia))
+		gomega.Expect(errs[1].Found).To(gomega.Equal("Barcelona"))
+	})
+
+	ginkgo.It("should report a filename that disagrees with the header", func() {
+		h, _ := header.Extract(bulletinText("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844", "BORME-A-2015-101-28"))
+		errs := h.Validate("BORME-A-2015-102-28.pdf")
+		gomega.Expect(errs).To(gomega.HaveLen(1))
+		gomega.Expect(errs[0].Source).To(gomega.Equal(header.SourceFilename))
+	})
+
+	ginkgo.It("should return ErrNoHeader without running header", func() {
+		_, err := header.Extract("57344 - ALDARA CATERING SL.")
+		gomega.Expect(err).To(gomega.MatchError(header.ErrNoHeader))
+	})
+
+	ginkgo.It("should recognise running lines", func() {
+		gomega.Expect(header.IsRunningLine("cve: BORME-A-2015-101-28")).To(gomega.BeTrue())
+		gomega.Expect(header.IsRunningLine("Núm. 101 Jueves 28 de mayo de 2015 Pág. 6844")).To(gomega.BeTrue())
+		gomega.Expect(header.IsRunningLine("57344 - ALDARA CATERING SL.")).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should strip running lines and page breaks", func() {
+		text := "57344 - ALDARA CATERING SL.\ncve: BORME-A-2015-101-28\nVerificable en http://www.boe.es\f" +
+			"BOLETÍN OFICIAL DEL REGISTRO MERCANTIL\nNúm. 101 Jueves 28 de mayo de 2015 Pág. 6844\n/F2 JAVIER."
+		gomega.Expect(header.Strip(text)).To(gomega.Equal("57344 - ALDARA CATERING SL.\n/F2 JAVIER."))
+	})
+
+	ginkgo.It("should fill the bulletin metadata when parsing", func() {
+		borme, err := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Num).To(gomega.Equal(101))
+		gomega.Expect(borme.Date).To(gomega.Equal(time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC)))
+		gomega.Expect(borme.CVE.String()).To(gomega.Equal("BORME-A-2015-101-28"))
+		gomega.Expect(borme.Provincia).To(gomega.Equal(&models.Provincia{Code: 280, Name: "Madrid", INE: 28}))
+	})
+})
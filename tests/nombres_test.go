@@ -0,0 +1,87 @@
+package gormeparser_test
+
+import (
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/nombres"
+	"github.com/argami/gormeparser/internal/parser/cargos"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Person names", func() {
+	ginkgo.It("should split surnames and compound given names", func() {
+		p := nombres.Parse("RAMA SANCHEZ JOSE PEDRO")
+		gomega.Expect(p.PrimerApellido).To(gomega.Equal("RAMA"))
+		gomega.Expect(p.SegundoApellido).To(gomega.Equal("SANCHEZ"))
+		gomega.Expect(p.Nombre).To(gomega.Equal("JOSE PEDRO"))
+		gomega.Expect(p.NombreConocido).To(gomega.BeTrue())
+		gomega.Expect(p.Display).To(gomega.Equal("Jose Pedro Rama Sanchez"))
+		gomega.Expect(p.Key).To(gomega.Equal("RAMA SANCHEZ JOSE PEDRO"))
+	})
+
+	ginkgo.It("should keep particles with their surname or given name", func() {
+		p := nombres.Parse("GARCIA DE LA TORRE MARIA DEL CARMEN")
+		gomega.Expect(p.PrimerApellido).To(gomega.Equal("GARCIA"))
+		gomega.Expect(p.SegundoApellido).To(gomega.Equal("DE LA TORRE"))
+		gomega.Expect(p.Nombre).To(gomega.Equal("MARIA DEL CARMEN"))
+		gomega.Expect(p.Display).To(gomega.Equal("Maria del Carmen Garcia de la Torre"))
+	})
+
+	ginkgo.It("should keep two surnames that are also given names", func() {
+		p := nombres.Parse("GARCIA MARTIN JUAN")
+		gomega.Expect(p.PrimerApellido).To(gomega.Equal("GARCIA"))
+		gomega.Expect(p.SegundoApellido).To(gomega.Equal("MARTIN"))
+		gomega.Expect(p.Nombre).To(gomega.Equal("JUAN"))
+	})
+
+	ginkgo.It("should keep hyphenated surnames together", func() {
+		p := nombres.Parse("LOPEZ-ARANDA RUIZ ANA")
+		gomega.Expect(p.PrimerApellido).To(gomega.Equal("LOPEZ-ARANDA"))
+		gomega.Expect(p.Display).To(gomega.Equal("Ana Lopez-Aranda Ruiz"))
+		gomega.Expect(p.Key).To(gomega.Equal("LOPEZ ARANDA RUIZ ANA"))
+	})
+
+	ginkgo.It("should split at the comma", func() {
+		p := nombres.Parse("SMITH, JOHN PAUL")
+		gomega.Expect(p.PrimerApellido).To(gomega.Equal("SMITH"))
+		gomega.Expect(p.SegundoApellido).To(gomega.BeEmpty())
+		gomega.Expect(p.Nombre).To(gomega.Equal("JOHN PAUL"))
+		gomega.Expect(p.NombreConocido).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should fall back to a positional split for unknown given names", func() {
+		p := nombres.Parse("ZUBIZARRETA ETXEBERRIA AITOR")
+		gomega.Expect(p.Nombre).To(gomega.Equal("AITOR"))
+		gomega.Expect(p.SegundoApellido).To(gomega.Equal("ETXEBERRIA"))
+		gomega.Expect(p.NombreConocido).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should match names regardless of accents and punctuation", func() {
+		gomega.Expect(nombres.Key("Muñoz Peña, José-María.")).To(gomega.Equal(nombres.Key("MUNOZ PENA JOSE MARIA")))
+		gomega.Expect(nombres.Parse("NÚÑEZ GÓMEZ BEGOÑA").Display).To(gomega.Equal("Begoña Núñez Gómez"))
+	})
+
+	ginkgo.It("should normalise the names of person holders", func() {
+		result := cargos.Parse("Consejero: FERNANDEZ RUIZ ANTONIO;INVERSIONES ALFA 2005 SL.")
+		holders := result[0].Holders
+		gomega.Expect(holders[0].Persona).ToNot(gomega.BeNil())
+		gomega.Expect(holders[0].Persona.Nombre).To(gomega.Equal("ANTONIO"))
+		gomega.Expect(holders[1].Persona).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should return an empty name for empty input", func() {
+		for _, name := range []string{"", " ", ".", " . "} {
+			gomega.Expect(nombres.Parse(name)).To(gomega.Equal(&models.NombrePersona{}), name)
+		}
+	})
+
+	ginkgo.It("should skip holders without a name", func() {
+		result := cargos.Parse("Consejero: ;.;")
+		for _, c := range result {
+			gomega.Expect(c.Holders).To(gomega.BeEmpty())
+		}
+
+		result = cargos.Parse("Consejero: FERNANDEZ RUIZ ANTONIO;.;MARTIN GIL LUIS.")
+		gomega.Expect(result[0].HolderNames()).To(gomega.Equal([]string{"FERNANDEZ RUIZ ANTONIO", "MARTIN GIL LUIS"}))
+	})
+})
@@ -0,0 +1,86 @@
+package gormeparser_test
+
+import (
+	"github.com/argami/gormeparser/internal/normalize"
+	"github.com/argami/gormeparser/internal/parser/pdftext"
+	"github.com/argami/gormeparser/internal/regex"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Text normalisation", func() {
+	ginkgo.Describe("UnescapePDF", func() {
+		ginkgo.It("should decode octal escapes as WinAnsi", func() {
+			gomega.Expect(normalize.UnescapePDF(`Constituci\363n \(S.L.\)`, nil)).To(gomega.Equal("Constitución (S.L.)"))
+			gomega.Expect(normalize.UnescapePDF(`Ca\244a \200 5`, nil)).To(gomega.Equal("Ca¤a € 5"))
+		})
+
+		ginkgo.It("should decode MacRoman and custom font encodings", func() {
+			gomega.Expect(normalize.UnescapePDF(`Constituci\227n`, normalize.MacRoman)).To(gomega.Equal("Constitución"))
+
+			custom := normalize.WinAnsi.WithDifferences(map[byte]rune{0x01: 'ñ'})
+			gomega.Expect(normalize.UnescapePDF(`Espa\001a`, custom)).To(gomega.Equal("España"))
+			gomega.Expect(normalize.EncodingByName("/MacRomanEncoding")).To(gomega.Equal(normalize.MacRoman))
+		})
+
+		ginkgo.It("should read glyph names", func() {
+			for name, want := range map[string]rune{"Oacute": 'Ó', "/ntilde": 'ñ', "uni00D3": 'Ó', "a": 'a', "Euro": '€'} {
+				r, ok := normalize.GlyphRune(name)
+				gomega.Expect(ok).To(gomega.BeTrue(), name)
+				gomega.Expect(r).To(gomega.Equal(want), name)
+			}
+			_, ok := normalize.GlyphRune("notaglyph")
+			gomega.Expect(ok).To(gomega.BeFalse())
+		})
+	})
+
+	ginkgo.Describe("FontEncodings", func() {
+		ginkgo.It("should read the encoding of each font resource", func() {
+			encodings := pdftext.FontEncodings("/F1 << /Type /Font /BaseFont /Helvetica-Bold /Encoding /MacRomanEncoding >>\n" +
+				"/F2 << /Type /Font /Encoding << /BaseEncoding /WinAnsiEncoding /Differences [ 1 /ntilde /Ntilde ] >> >>\n" +
+				"/F3 << /Type /Font /BaseFont /Helvetica >>\n" +
+				"/F2 Adm. Unico: PE\\001A JORDI.")
+			gomega.Expect(encodings).To(gomega.HaveLen(2))
+			gomega.Expect(encodings["/F1"]).To(gomega.Equal(normalize.MacRoman))
+			gomega.Expect(normalize.UnescapePDF(`PE\002A pe\001a \323`, encodings["/F2"])).To(gomega.Equal("PEÑA peña Ó"))
+		})
+
+		ginkgo.It("should tell font resources from text", func() {
+			gomega.Expect(pdftext.IsFontResource("/F1 << /Type /Font >>")).To(gomega.BeTrue())
+			gomega.Expect(pdftext.IsFontResource("/F1 Nombramientos")).To(gomega.BeFalse())
+		})
+	})
+
+	ginkgo.Describe("Text", func() {
+		ginkgo.It("should expand ligatures and compose accents", func() {
+			gomega.Expect(normalize.Text("  Déﬁcit   ﬁnanciero ")).To(gomega.Equal("Déficit financiero"))
+		})
+
+		ginkgo.It("should remove soft hyphens inside words", func() {
+			gomega.Expect(normalize.Text("adminis\u00adtración")).To(gomega.Equal("administración"))
+		})
+
+		ginkgo.It("should keep line breaks", func() {
+			gomega.Expect(normalize.Text("Primero.\n\n  Segundo.")).To(gomega.Equal("Primero.\nSegundo."))
+		})
+	})
+
+	ginkgo.It("should rejoin words split by a soft hyphen", func() {
+		lines := []string{
+			regex.CleanPDFText("Objeto social: la adminis\u00ad"),
+			regex.CleanPDFText("tración de fincas."),
+		}
+		gomega.Expect(regex.JoinLines(lines)).To(gomega.Equal("Objeto social: la administración de fincas."))
+	})
+
+	ginkgo.It("should fold accents keeping Ñ", func() {
+		gomega.Expect(normalize.FoldAccents("Cesión global Ñandú Çà")).To(gomega.Equal("Cesion global Ñandu Ca"))
+		gomega.Expect(normalize.FoldAccents("Peña")).To(gomega.Equal("Peña"))
+		gomega.Expect(normalize.Key(" Cesión  global ")).To(gomega.Equal("CESION GLOBAL"))
+	})
+
+	ginkgo.It("should capitalize accented first letters", func() {
+		gomega.Expect(normalize.Capitalize("ÁREA DE NEGOCIO")).To(gomega.Equal("Área de negocio"))
+		gomega.Expect(regex.CapitalizeSentence("éxito")).To(gomega.Equal("Éxito"))
+	})
+})
@@ -1,8 +1,10 @@
 package gormeparser_test
 
 import (
+	"os"
 	"time"
 
+	"github.com/argami/gormeparser/internal/models"
 	"github.com/argami/gormeparser/internal/parser/pypdf2"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -39,6 +41,125 @@ var _ = ginkgo.Describe("Parser Router", func() {
 			gomega.Expect(parser).ToNot(gomega.BeNil())
 		})
 
+		ginkgo.It("should parse company headers and attach actos", func() {
+			borme, err := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.Anuncios).To(gomega.HaveLen(3))
+
+			var empresas []string
+			for _, a := range borme.Anuncios {
+				empresas = append(empresas, a.EmpresaNormalizada)
+				gomega.Expect(a.Actos).ToNot(gomega.BeEmpty())
+			}
+			gomega.Expect(empresas).To(gomega.ConsistOf(
+				"ALDARA CATERING SL",
+				"INVERSIONES Y PROMOCIONES INMOBILIARIAS DEL MEDITERRANEO SL",
+				"NORDWIND HANDEL GMBH",
+			))
+		})
+
+		ginkgo.It("should keep the published anuncio numbers", func() {
+			borme, _ := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(57344))
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(57345))
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(57346))
+			gomega.Expect(borme.Anuncios[57344].Empresa).To(gomega.Equal("ALDARA CATERING SL"))
+			gomega.Expect(borme.AnunciosRango).To(gomega.Equal([2]int{57344, 57346}))
+		})
+
+		ginkgo.It("should keep the first of duplicated anuncio numbers", func() {
+			borme, _ := pypdf2.NewParser("testdata/anuncios_duplicados.txt").Parse()
+			gomega.Expect(borme.Anuncios).To(gomega.HaveLen(1))
+			gomega.Expect(borme.Anuncios[57344].Empresa).To(gomega.Equal("ALDARA CATERING SL"))
+			gomega.Expect(borme.Anuncios[57344].Actos).To(gomega.HaveLen(1))
+		})
+
+		ginkgo.It("should keep the duplicated anuncios with their actos", func() {
+			borme, _ := pypdf2.NewParser("testdata/anuncios_duplicados.txt").Parse()
+			gomega.Expect(borme.Duplicados).To(gomega.HaveLen(1))
+			gomega.Expect(borme.Duplicados[0].ID).To(gomega.Equal(57344))
+			gomega.Expect(borme.Duplicados[0].Empresa).To(gomega.Equal("TRANSPORTES VEGA SL"))
+			gomega.Expect(borme.Duplicados[0].Actos).To(gomega.HaveLen(1))
+		})
+
+		ginkgo.It("should set sucursal and liquidacion flags", func() {
+			borme, _ := pypdf2.NewParser("testdata/BORME-A-2015-101-28.txt").Parse()
+			for _, a := range borme.Anuncios {
+				switch a.EmpresaNormalizada {
+				case "NORDWIND HANDEL GMBH":
+					gomega.Expect(a.Sucursal).To(gomega.BeTrue())
+					gomega.Expect(a.Registro).To(gomega.Equal("Madrid"))
+				case "INVERSIONES Y PROMOCIONES INMOBILIARIAS DEL MEDITERRANEO SL":
+					gomega.Expect(a.Liquidacion).To(gomega.BeTrue())
+				default:
+					gomega.Expect(a.Sucursal).To(gomega.BeFalse())
+					gomega.Expect(a.Liquidacion).To(gomega.BeFalse())
+				}
+			}
+		})
+
+		ginkgo.It("should join anuncios crossing a page break", func() {
+			borme, err := pypdf2.NewParser("testdata/BORME-A-2015-102-08.txt").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.Anuncios).To(gomega.HaveLen(2))
+
+			anuncio := borme.Anuncios[58001]
+			gomega.Expect(anuncio.Actos).To(gomega.HaveLen(2))
+			gomega.Expect(anuncio.Actos[0].GetName()).To(gomega.Equal("Constitución"))
+			gomega.Expect(anuncio.Actos[0].GetValue()).To(gomega.Equal(
+				"Comienzo de operaciones: 1.05.15. Objeto social: La administración de fincas. " +
+					"Domicilio: C/ MAYOR 1 (BARCELONA). Capital: 3.000,00 Euros."))
+
+			nombramientos, ok := anuncio.Actos[1].(*models.BormeActoCargo)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			gomega.Expect(nombramientos.Value).To(gomega.HaveLen(1))
+			gomega.Expect(nombramientos.Value[0].HolderNames()).To(gomega.Equal([]string{
+				"PUIG FERRER JORDI", "PUIG FERRER MONTSERRAT",
+			}))
+		})
+
+		ginkgo.It("should keep hyphens of upper-case names split across lines", func() {
+			borme, _ := pypdf2.NewParser("testdata/BORME-A-2015-102-08.txt").Parse()
+			anuncio := borme.Anuncios[58002]
+			gomega.Expect(anuncio.Empresa).To(gomega.Equal("TALLERS GARCIA-LOPEZ SL"))
+			ceses := anuncio.Actos[0].(*models.BormeActoCargo)
+			gomega.Expect(ceses.Value[0].HolderNames()).To(gomega.Equal([]string{"GARCIA-LOPEZ PERE"}))
+		})
+
+		ginkgo.It("should join a header split after a legal form initial", func() {
+			filename := ginkgo.GinkgoT().TempDir() + "/BORME-A-2015-101-28.txt"
+			text := "Cabecera\n57347 - ACME S.\nL.\nTexto\n/F1 Nombramientos\n/F2 Adm. Unico: PUIG JORDI.\n"
+			gomega.Expect(os.WriteFile(filename, []byte(text), 0644)).To(gomega.Succeed())
+
+			borme, err := pypdf2.NewParser(filename).Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.Anuncios).To(gomega.HaveKey(57347))
+			gomega.Expect(borme.Anuncios[57347].EmpresaNormalizada).To(gomega.Equal("ACME SL"))
+			for _, d := range borme.Diagnostics {
+				gomega.Expect(d.Message).ToNot(gomega.ContainSubstring("unrecognised anuncio header"))
+			}
+		})
+
+		ginkgo.It("should decode escaped codes with the encoding of the font", func() {
+			filename := ginkgo.GinkgoT().TempDir() + "/BORME-A-2015-101-28.txt"
+			text := "/F1 << /Type /Font /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\n" +
+				"/F2 << /Type /Font /Encoding << /Differences [ 1 /Ntilde ] >> >>\n" +
+				"Cabecera\n57348 - CONSTRUCCIONES G\\323MEZ SL.\nTexto\n" +
+				"/F1 Constituci\\363n\n/F2 Objeto social: Construcci\\363n. Capital: 3.006,00 Euros.\n" +
+				"/F1 Nombramientos\n/F2 Adm. Unico: PE\\001A PUIG JORDI.\n"
+			gomega.Expect(os.WriteFile(filename, []byte(text), 0644)).To(gomega.Succeed())
+
+			borme, err := pypdf2.NewParser(filename).Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			anuncio := borme.Anuncios[57348]
+			gomega.Expect(anuncio.Empresa).To(gomega.Equal("CONSTRUCCIONES GÓMEZ SL"))
+			gomega.Expect(anuncio.Actos[0].GetName()).To(gomega.Equal("Constitución"))
+			gomega.Expect(anuncio.Actos[0].GetValue()).To(gomega.ContainSubstring("Construcción."))
+			cargos := anuncio.Actos[1].(*models.BormeActoCargo)
+			gomega.Expect(cargos.Value[0].HolderNames()).To(gomega.Equal([]string{"PEÑA PUIG JORDI"}))
+		})
+
 		ginkgo.It("should handle non-existent file gracefully", func() {
 			parser := pypdf2.NewParser("testdata/nonexistent.pdf")
 			result, err := parser.Parse()
@@ -0,0 +1,67 @@
+package gormeparser_test
+
+import (
+	"encoding/json"
+
+	"github.com/argami/gormeparser/internal/parser/pdftext"
+	"github.com/argami/gormeparser/internal/parser/pypdf2"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Provenance", func() {
+	const filename = "testdata/BORME-A-2015-102-08.txt"
+
+	ginkgo.It("should not be recorded by default", func() {
+		borme, err := pypdf2.NewParser(filename).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		anuncio := borme.Anuncios[58001]
+		gomega.Expect(anuncio.GetProvenance()).To(gomega.BeNil())
+		gomega.Expect(anuncio.Actos[0].GetProvenance()).To(gomega.BeNil())
+
+		data, err := json.Marshal(anuncio)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(string(data)).ToNot(gomega.ContainSubstring("provenance"))
+	})
+
+	ginkgo.It("should record the pages and raw text of each anuncio", func() {
+		borme, err := pypdf2.NewParser(filename, pypdf2.WithProvenance(true)).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		prov := borme.Anuncios[58001].GetProvenance()
+		gomega.Expect(prov).ToNot(gomega.BeNil())
+		gomega.Expect(prov.Page).To(gomega.Equal(1))
+		gomega.Expect(prov.EndPage).To(gomega.Equal(2))
+		gomega.Expect(prov.Raw).To(gomega.HavePrefix("58001 - PUIG FERRER GESTIO SL."))
+		gomega.Expect(prov.Raw).To(gomega.HaveSuffix("/F2 PUIG FERRER MONTSERRAT."))
+		gomega.Expect(prov.Raw).To(gomega.ContainSubstring("Pág. 7013"))
+
+		prov = borme.Anuncios[58002].GetProvenance()
+		gomega.Expect(prov.Page).To(gomega.Equal(2))
+		gomega.Expect(prov.EndPage).To(gomega.BeZero())
+		gomega.Expect(prov.Raw).To(gomega.HavePrefix("58002 - TALLERS GARCIA-\nLOPEZ SL."))
+		gomega.Expect(prov.Raw).To(gomega.HaveSuffix("/F2 LOPEZ PERE."))
+	})
+
+	ginkgo.It("should record offsets of each acto into the extracted text", func() {
+		text, err := pdftext.ReadText(filename)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		borme, err := pypdf2.NewParser(filename, pypdf2.WithProvenance(true)).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		actos := borme.Anuncios[58001].Actos
+		constitucion := actos[0].GetProvenance()
+		gomega.Expect(constitucion.Page).To(gomega.Equal(1))
+		gomega.Expect(constitucion.EndPage).To(gomega.Equal(2))
+		gomega.Expect(text[constitucion.Start:constitucion.End]).To(gomega.Equal(constitucion.Raw))
+		gomega.Expect(constitucion.Raw).To(gomega.HavePrefix("/F1 Constitución."))
+		gomega.Expect(constitucion.Raw).To(gomega.HaveSuffix("Capital: 3.000,00 Euros."))
+
+		nombramientos := actos[1].GetProvenance()
+		gomega.Expect(nombramientos.Page).To(gomega.Equal(2))
+		gomega.Expect(nombramientos.EndPage).To(gomega.BeZero())
+		gomega.Expect(nombramientos.Raw).To(gomega.Equal(
+			"/F1 Nombramientos.\n/F2 Adm. Solid.: PUIG FERRER JORDI;\n/F2 PUIG FERRER MONTSERRAT."))
+	})
+})
@@ -3,6 +3,7 @@ package gormeparser_test
 import (
 	"time"
 
+	"github.com/argami/gormeparser/internal/models"
 	"github.com/argami/gormeparser/internal/regex"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -32,6 +33,49 @@ var _ = ginkgo.Describe("Regex", func() {
 		})
 	})
 
+	ginkgo.Describe("ParseCabecera", func() {
+		ginkgo.It("should parse a plain header", func() {
+			m := regex.ParseCabecera("57344 - ALDARA CATERING SL.")
+			gomega.Expect(m).ToNot(gomega.BeNil())
+			gomega.Expect(m.ID).To(gomega.Equal("57344"))
+			gomega.Expect(m.Name).To(gomega.Equal("ALDARA CATERING SL"))
+			gomega.Expect(m.Sucursal).To(gomega.BeFalse())
+			gomega.Expect(m.Liquidacion).To(gomega.BeFalse())
+		})
+
+		ginkgo.It("should detect liquidacion and normalize the name", func() {
+			m := regex.ParseCabecera("57345 - CONSTRUCCIONES RIVAS, S.L. EN LIQUIDACION.")
+			gomega.Expect(m.Name).To(gomega.Equal("CONSTRUCCIONES RIVAS, S.L. EN LIQUIDACION"))
+			gomega.Expect(m.Liquidacion).To(gomega.BeTrue())
+			gomega.Expect(m.Normalizada).To(gomega.Equal("CONSTRUCCIONES RIVAS SL"))
+		})
+
+		ginkgo.It("should detect sucursal and registro variants", func() {
+			m := regex.ParseCabecera("57346 - NORDWIND HANDEL GMBH SUCURSAL EN ESPAÑA(R.M.: Palma de Mallorca).")
+			gomega.Expect(m.Sucursal).To(gomega.BeTrue())
+			gomega.Expect(m.Registro).To(gomega.Equal("Palma de Mallorca"))
+			gomega.Expect(m.Normalizada).To(gomega.Equal("NORDWIND HANDEL GMBH"))
+		})
+
+		ginkgo.It("should return nil for non-header lines", func() {
+			gomega.Expect(regex.ParseCabecera("Nombramientos")).To(gomega.BeNil())
+		})
+	})
+
+	ginkgo.Describe("CabeceraCompleta", func() {
+		ginkgo.It("should accept headers ending with the final dot or the register", func() {
+			gomega.Expect(regex.CabeceraCompleta("57344 - ALDARA CATERING SL.")).To(gomega.BeTrue())
+			gomega.Expect(regex.CabeceraCompleta("57344 - ALDARA CATERING S. L.")).To(gomega.BeTrue())
+			gomega.Expect(regex.CabeceraCompleta("57346 - NORDWIND HANDEL GMBH(R.M. Madrid)")).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should reject headers split inside the name or legal form", func() {
+			gomega.Expect(regex.CabeceraCompleta("57345 - INVERSIONES Y PROMOCIONES INMOBILIARIAS DEL")).To(gomega.BeFalse())
+			gomega.Expect(regex.CabeceraCompleta("57347 - ACME S.")).To(gomega.BeFalse())
+			gomega.Expect(regex.CabeceraCompleta("Nombramientos.")).To(gomega.BeFalse())
+		})
+	})
+
 	ginkgo.Describe("ParseCargos", func() {
 		ginkgo.It("should parse cargo with single person", func() {
 			cargos := regex.ParseCargos("Adm. Solid.: JUAN PEREZ")
@@ -60,6 +104,63 @@ var _ = ginkgo.Describe("Regex", func() {
 			gomega.Expect(t.Month()).To(gomega.Equal(time.January))
 			gomega.Expect(t.Year()).To(gomega.Equal(2021))
 		})
+
+		ginkgo.It("should parse dates without a weekday", func() {
+			t, err := regex.ParseFecha("30 de junio de 2015")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(t).To(gomega.Equal(time.Date(2015, time.June, 30, 0, 0, 0, 0, time.UTC)))
+		})
+
+		ginkgo.It("should parse accented weekdays", func() {
+			t, err := regex.ParseFecha("Miércoles 27 de mayo de 2015")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(t.Day()).To(gomega.Equal(27))
+		})
+
+		ginkgo.It("should return an error for invalid dates", func() {
+			_, err := regex.ParseFecha("cve: BORME-A-2015-101-28")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			_, err = regex.ParseFecha("Martes 2 de brumario de 2015")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			_, err = regex.ParseFecha("Martes 31 de junio de 2015")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+
+	ginkgo.Describe("TrimFinalDot", func() {
+		ginkgo.It("should keep the dot of a trailing legal form", func() {
+			gomega.Expect(regex.TrimFinalDot("ACME SL.")).To(gomega.Equal("ACME SL"))
+			gomega.Expect(regex.TrimFinalDot("ACME S.L.")).To(gomega.Equal("ACME S.L."))
+			gomega.Expect(regex.TrimFinalDot("ACME S. L. ")).To(gomega.Equal("ACME S. L."))
+		})
+	})
+
+	ginkgo.Describe("JoinLines", func() {
+		ginkgo.It("should join wrapped lines with spaces", func() {
+			gomega.Expect(regex.JoinLines([]string{"Adm. Solid.: PUIG FERRER JORDI;", " PUIG FERRER MONTSERRAT.", ""})).
+				To(gomega.Equal("Adm. Solid.: PUIG FERRER JORDI; PUIG FERRER MONTSERRAT."))
+		})
+
+		ginkgo.It("should rejoin hyphenated lower-case words", func() {
+			gomega.Expect(regex.JoinLines([]string{"La adminis-", "tración de fincas"})).
+				To(gomega.Equal("La administración de fincas"))
+		})
+
+		ginkgo.It("should keep the hyphen of compound names", func() {
+			gomega.Expect(regex.JoinLines([]string{"GARCIA-", "LOPEZ PERE"})).To(gomega.Equal("GARCIA-LOPEZ PERE"))
+		})
+
+		ginkgo.It("should join long anuncios line by line", func() {
+			lines := make([]string, 20000)
+			for i := range lines {
+				lines[i] = "GARCIA LOPEZ PERE;"
+			}
+			lines = append(lines, "adminis-", "tración")
+			joined := regex.JoinLines(lines)
+			gomega.Expect(joined).To(gomega.HavePrefix("GARCIA LOPEZ PERE; GARCIA"))
+			gomega.Expect(joined).To(gomega.HaveSuffix("PERE; administración"))
+			gomega.Expect(joined).To(gomega.HaveLen(20000*len("GARCIA LOPEZ PERE; ") + len("administración")))
+		})
 	})
 
 	ginkgo.Describe("IsCompany", func() {
@@ -79,6 +180,50 @@ var _ = ginkgo.Describe("Regex", func() {
 		})
 	})
 
+	ginkgo.Describe("FormaJuridica", func() {
+		ginkgo.It("should detect Spanish legal forms", func() {
+			gomega.Expect(regex.FormaJuridica("ACME SL")).To(gomega.Equal(models.FormaJuridicaSL))
+			gomega.Expect(regex.FormaJuridica("ACME, S.L.U.")).To(gomega.Equal(models.FormaJuridicaSLU))
+			gomega.Expect(regex.FormaJuridica("TALLERES UNIDOS S.L.L.")).To(gomega.Equal(models.FormaJuridicaSLL))
+			gomega.Expect(regex.FormaJuridica("ACME SOCIEDAD LIMITADA NUEVA EMPRESA")).To(gomega.Equal(models.FormaJuridicaSLNE))
+			gomega.Expect(regex.FormaJuridica("BANCO EJEMPLO SOCIEDAD ANÓNIMA")).To(gomega.Equal(models.FormaJuridicaSA))
+			gomega.Expect(regex.FormaJuridica("COSECHEROS DEL SUR S.COOP.AND.")).To(gomega.Equal(models.FormaJuridicaSCOOP))
+			gomega.Expect(regex.FormaJuridica("UTE NORTE AIE")).To(gomega.Equal(models.FormaJuridicaAIE))
+		})
+
+		ginkgo.It("should detect foreign legal forms", func() {
+			gomega.Expect(regex.FormaJuridica("BETA HOLDING GMBH")).To(gomega.Equal(models.FormaJuridicaGMBH))
+			gomega.Expect(regex.FormaJuridica("GAMMA INVESTMENTS LIMITED")).To(gomega.Equal(models.FormaJuridicaLTD))
+			gomega.Expect(regex.FormaJuridica("DELTA B.V.")).To(gomega.Equal(models.FormaJuridicaBV))
+		})
+
+		ginkgo.It("should return empty for person names", func() {
+			gomega.Expect(regex.FormaJuridica("RAMA SANCHEZ JOSE PEDRO")).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should not read initials as a two-letter legal form", func() {
+			gomega.Expect(regex.FormaJuridica("LARSSON PER A B")).To(gomega.BeEmpty())
+			gomega.Expect(regex.FormaJuridica("VAN DIJK JAN N V")).To(gomega.BeEmpty())
+			gomega.Expect(regex.FormaJuridica("NORDIC TRADING AB")).To(gomega.Equal(models.FormaJuridicaAB))
+			gomega.Expect(regex.FormaJuridica("OMEGA A.G.")).To(gomega.Equal(models.FormaJuridicaAG))
+			gomega.Expect(regex.FormaJuridica("ALFA S. A.")).To(gomega.Equal(models.FormaJuridicaSA))
+			gomega.Expect(regex.NormalizeEmpresa("ALFA S. L.")).To(gomega.Equal("ALFA SL"))
+		})
+	})
+
+	ginkgo.Describe("ClassifyEntity", func() {
+		ginkgo.It("should classify foundations as legal entities", func() {
+			kind, forma := regex.ClassifyEntity("FUNDACIÓN AMIGOS DEL MAR")
+			gomega.Expect(kind).To(gomega.Equal(models.EntityEmpresa))
+			gomega.Expect(forma).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should classify people as natural persons", func() {
+			kind, _ := regex.ClassifyEntity("RAMA SANCHEZ JOSE PEDRO")
+			gomega.Expect(kind).To(gomega.Equal(models.EntityPersona))
+		})
+	})
+
 	ginkgo.Describe("CleanPDFText", func() {
 		ginkgo.It("should unescape parentheses", func() {
 			cleaned := regex.CleanPDFText("Constitucion \\(Sociedad Limitada\\)")
@@ -0,0 +1,87 @@
+package gormeparser_test
+
+import (
+	"os"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser"
+	"github.com/argami/gormeparser/internal/parser/seccion_b"
+	"github.com/argami/gormeparser/internal/regex"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Section B Parser", func() {
+	var borme *models.BormeB
+
+	ginkgo.BeforeEach(func() {
+		var err error
+		borme, err = seccionb.NewParser("testdata/BORME-B-2015-101-28.txt").Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should set the section and subsection", func() {
+		gomega.Expect(borme.Seccion).To(gomega.Equal(models.SeccionB))
+		gomega.Expect(borme.Subseccion).To(gomega.Equal(models.SubseccionOtrosActos))
+		gomega.Expect(borme.Entradas).To(gomega.HaveLen(4))
+	})
+
+	ginkgo.It("should parse capital reductions", func() {
+		entradas := borme.EntradasByTipo(models.TipoEntradaBReduccionCapital)
+		gomega.Expect(entradas).To(gomega.HaveLen(1))
+		gomega.Expect(entradas[0].ID).To(gomega.Equal(430100))
+		gomega.Expect(entradas[0].Empresa).To(gomega.Equal("ACEITES DEL SUR SL"))
+		gomega.Expect(entradas[0].Subseccion).To(gomega.Equal("Reducciones de capital"))
+		gomega.Expect(entradas[0].Importe).To(gomega.Equal(60000.0))
+		gomega.Expect(entradas[0].CapitalResultante).To(gomega.Equal(3006.0))
+	})
+
+	ginkgo.It("should parse absorbed companies", func() {
+		entradas := borme.EntradasByTipo(models.TipoEntradaBSociedadAbsorbida)
+		gomega.Expect(entradas).To(gomega.HaveLen(1))
+		gomega.Expect(entradas[0].Empresa).To(gomega.Equal("DISTRIBUCIONES NORTE SL"))
+		gomega.Expect(entradas[0].Absorbente).To(gomega.Equal("GRUPO NORTE S.A."))
+	})
+
+	ginkgo.It("should parse the fiscal year of account deposits", func() {
+		entradas := borme.EntradasByTipo(models.TipoEntradaBDepositoCuentas)
+		gomega.Expect(entradas).To(gomega.HaveLen(2))
+		gomega.Expect(entradas[0].Empresa).To(gomega.Equal("CONSTRUCCIONES DELTA SL"))
+		gomega.Expect(entradas[0].Ejercicio).To(gomega.Equal(2014))
+		gomega.Expect(entradas[1].Empresa).To(gomega.Equal("TALLERES OMEGA SA"))
+		gomega.Expect(entradas[1].Ejercicio).To(gomega.Equal(2013))
+	})
+
+	ginkgo.It("should decode escaped codes", func() {
+		filename := ginkgo.GinkgoT().TempDir() + "/BORME-B-2015-101-28.txt"
+		text := "/F2 << /Type /Font /Encoding /WinAnsiEncoding >>\n" +
+			"Cabecera\n/F1 Sociedades absorbidas\nTexto\n" +
+			"/F2 430101 - DISTRIBUCIONES NU\\321EZ SL.\n/F2 Sociedad absorbente: GRUPO G\\323MEZ S.A.\n"
+		gomega.Expect(os.WriteFile(filename, []byte(text), 0644)).To(gomega.Succeed())
+
+		borme, err := seccionb.NewParser(filename).Parse()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(borme.Entradas).To(gomega.HaveLen(1))
+		gomega.Expect(borme.Entradas[0].Empresa).To(gomega.Equal("DISTRIBUCIONES NUÑEZ SL"))
+		gomega.Expect(borme.Entradas[0].Absorbente).To(gomega.Equal("GRUPO GÓMEZ S.A."))
+	})
+
+	ginkgo.It("should be used by the router for Section B", func() {
+		result, err := parser.Parse("testdata/BORME-B-2015-101-28.txt", models.SeccionB)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(result).To(gomega.BeAssignableToTypeOf(&models.BormeB{}))
+	})
+})
+
+var _ = ginkgo.Describe("ParseImporte", func() {
+	ginkgo.It("should parse Spanish amounts", func() {
+		v, ok := regex.ParseImporte("1.234.567,89")
+		gomega.Expect(ok).To(gomega.BeTrue())
+		gomega.Expect(v).To(gomega.Equal(1234567.89))
+	})
+
+	ginkgo.It("should reject non-numeric text", func() {
+		_, ok := regex.ParseImporte("Euros")
+		gomega.Expect(ok).To(gomega.BeFalse())
+	})
+})
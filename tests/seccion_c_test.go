@@ -1,6 +1,13 @@
 package gormeparser_test
 
 import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/argami/gormeparser/internal/models"
+	"github.com/argami/gormeparser/internal/parser"
 	seccionc "github.com/argami/gormeparser/internal/parser/seccion_c"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -20,7 +27,221 @@ var _ = ginkgo.Describe("Section C Parser", func() {
 		})
 	})
 
+	ginkgo.Describe("Parse", func() {
+		var borme *models.BormeC
+
+		ginkgo.BeforeEach(func() {
+			var err error
+			borme, err = seccionc.NewParser("testdata/BORME-C-2011-20488.xml").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should read the documento metadata", func() {
+			gomega.Expect(borme.CVE.String()).To(gomega.Equal("BORME-C-2011-20488"))
+			gomega.Expect(borme.NumeroAnuncio).To(gomega.Equal("20488"))
+			gomega.Expect(borme.Seccion).To(gomega.Equal(models.SeccionC))
+			gomega.Expect(borme.DiarioNumero).To(gomega.Equal(110))
+			gomega.Expect(borme.Departamento).To(gomega.Equal("FUSIONES Y ABSORCIONES DE EMPRESAS"))
+			gomega.Expect(borme.Fecha).To(gomega.Equal(time.Date(2011, time.June, 10, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(borme.PaginaInicial).To(gomega.Equal(21622))
+			gomega.Expect(borme.PaginaFinal).To(gomega.Equal(21623))
+			gomega.Expect(borme.URLPDF).To(gomega.Equal("/borme/dias/2011/06/10/pdfs/BORME-C-2011-20488.pdf"))
+			gomega.Expect(*borme.Filename).To(gomega.Equal("testdata/BORME-C-2011-20488.xml"))
+		})
+
+		ginkgo.It("should join the paragraphs of the text", func() {
+			parrafos := strings.Split(borme.Texto, "\n")
+			gomega.Expect(parrafos).To(gomega.HaveLen(5))
+			gomega.Expect(parrafos[0]).To(gomega.Equal("Anuncio de fusión por absorción."))
+			gomega.Expect(parrafos[1]).To(gomega.ContainSubstring("sobre modificaciones estructurales"))
+			gomega.Expect(borme.IDAnuncio).To(gomega.Equal("A110044738"))
+			gomega.Expect(borme.CIFs).To(gomega.Equal([]string{"B46123451", "B97654321"}))
+		})
+
+		ginkgo.It("should split the companies of the title", func() {
+			gomega.Expect(borme.Empresa).To(gomega.Equal("DISTRIBUCIONES COMERCIALES LEVANTE, S.L."))
+			gomega.Expect(borme.EmpresasRelacionadas).To(gomega.Equal([]string{"SUMINISTROS INDUSTRIALES DEL TURIA, S.L."}))
+		})
+	})
+
+	ginkgo.Describe("Diagnostics", func() {
+		var filename string
+
+		ginkgo.BeforeEach(func() {
+			data, err := os.ReadFile("testdata/BORME-C-2011-20488.xml")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			xml := strings.NewReplacer(
+				"<identificador>BORME-C-2011-20488<", "<identificador>BORME-X-2011<",
+				"<fecha_publicacion>20110610<", "<fecha_publicacion>10/06/2011<",
+			).Replace(string(data))
+			filename = ginkgo.GinkgoT().TempDir() + "/BORME-C-2011-20488.xml"
+			gomega.Expect(os.WriteFile(filename, []byte(xml), 0644)).To(gomega.Succeed())
+		})
+
+		ginkgo.It("should report invalid metadata", func() {
+			borme, err := seccionc.NewParser(filename).Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.CVE.IsZero()).To(gomega.BeTrue())
+			gomega.Expect(borme.Fecha.IsZero()).To(gomega.BeTrue())
+
+			diags := borme.Diagnostics.Filter(models.SeverityWarning)
+			gomega.Expect(diags).To(gomega.HaveLen(2))
+			gomega.Expect(diags[0].Snippet).To(gomega.Equal("BORME-X-2011"))
+			gomega.Expect(diags[1].Snippet).To(gomega.Equal("10/06/2011"))
+		})
+
+		ginkgo.It("should fail in strict mode", func() {
+			_, err := parser.ParseWith(filename, models.SeccionC, parser.Options{Strict: true})
+			var diagErr *models.DiagnosticsError
+			gomega.Expect(errors.As(err, &diagErr)).To(gomega.BeTrue())
+			gomega.Expect(diagErr.Diagnostics).To(gomega.HaveLen(2))
+		})
+
+		ginkgo.It("should report invalid page metadata", func() {
+			data, err := os.ReadFile("testdata/BORME-C-2015-6112.html")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			page := strings.NewReplacer(
+				"25 de mayo de 2015, página", "25 de mayu de 2015, página",
+				"<dd>BORME-C-2015-6112\n", "<dd>BORME-C-2015\n",
+			).Replace(string(data))
+			filename := ginkgo.GinkgoT().TempDir() + "/BORME-C-2015-6112.html"
+			gomega.Expect(os.WriteFile(filename, []byte(page), 0644)).To(gomega.Succeed())
+
+			borme, err := seccionc.NewParser(filename).Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.DiarioNumero).To(gomega.Equal(96))
+			diags := borme.Diagnostics.Filter(models.SeverityWarning)
+			gomega.Expect(diags).To(gomega.HaveLen(2))
+			gomega.Expect(diags[0].Snippet).To(gomega.Equal("25 de mayu de 2015"))
+			gomega.Expect(diags[1].Snippet).To(gomega.Equal("BORME-C-2015"))
+
+			_, err = seccionc.NewParser(filename, seccionc.WithStrict(true)).Parse()
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should report nothing for a valid document", func() {
+			for _, name := range []string{"BORME-C-2011-20488.xml", "BORME-C-2011-20488.html", "BORME-C-2015-6112.html"} {
+				borme, err := seccionc.NewParser("testdata/"+name, seccionc.WithStrict(true)).Parse()
+				gomega.Expect(err).ToNot(gomega.HaveOccurred(), name)
+				gomega.Expect(borme.Diagnostics).To(gomega.BeEmpty(), name)
+			}
+		})
+	})
+
+	// The HTML fixtures follow the structure of the txt.php pages, trimmed
+	// to the parts the parser reads; they are not complete saved pages.
+	ginkgo.Describe("Parse HTML", func() {
+		ginkgo.It("should extract the same fields as the XML", func() {
+			xml, err := seccionc.NewParser("testdata/BORME-C-2011-20488.xml").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			borme, err := seccionc.NewParser("testdata/BORME-C-2011-20488.html").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			borme.Filename = xml.Filename
+			gomega.Expect(borme).To(gomega.Equal(xml))
+		})
+
+		ginkgo.It("should parse single-page announcements", func() {
+			borme, err := seccionc.NewParser("testdata/BORME-C-2015-6112.html").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.CVE.String()).To(gomega.Equal("BORME-C-2015-6112"))
+			gomega.Expect(borme.Departamento).To(gomega.Equal("CONVOCATORIAS DE JUNTAS"))
+			gomega.Expect(borme.Fecha).To(gomega.Equal(time.Date(2015, time.May, 25, 0, 0, 0, 0, time.UTC)))
+			gomega.Expect(borme.PaginaInicial).To(gomega.Equal(7304))
+			gomega.Expect(borme.PaginaFinal).To(gomega.Equal(7304))
+			gomega.Expect(borme.Empresa).To(gomega.Equal("CONSTRUCCIONES Y PROMOCIONES ALBAIDA, S.A."))
+			gomega.Expect(borme.EmpresasRelacionadas).To(gomega.BeEmpty())
+			gomega.Expect(borme.IDAnuncio).To(gomega.Equal("A150021734"))
+			gomega.Expect(borme.Texto).To(gomega.ContainSubstring("\nOrden del día\nPrimero.- Examen"))
+			gomega.Expect(borme.Texto).To(gomega.ContainSubstring("calle Virgen de Luján, número 12"))
+		})
+	})
+
+	ginkgo.Describe("Classify", func() {
+		ginkgo.It("should classify by the department heading", func() {
+			gomega.Expect(seccionc.Classify("CONVOCATORIAS DE JUNTAS", "ALFA, S.A.", "")).To(gomega.Equal(models.TipoCConvocatoriaJunta))
+			gomega.Expect(seccionc.Classify("ESCISIÓN DE EMPRESAS", "ALFA, S.L.", "")).To(gomega.Equal(models.TipoCEscision))
+			gomega.Expect(seccionc.Classify("REDUCCIÓN DE CAPITAL", "ALFA, S.L.", "")).To(gomega.Equal(models.TipoCReduccionCapital))
+			gomega.Expect(seccionc.Classify("DISOLUCIÓN Y LIQUIDACIÓN DE EMPRESAS", "ALFA, S.L.", "")).To(gomega.Equal(models.TipoCDisolucion))
+			gomega.Expect(seccionc.Classify("CAMBIO DE DOMICILIO SOCIAL", "ALFA, S.L.", "")).To(gomega.Equal(models.TipoCCambioDomicilio))
+		})
+
+		ginkgo.It("should classify generic headings by the text", func() {
+			gomega.Expect(seccionc.Classify("OTROS ANUNCIOS Y AVISOS LEGALES", "BANCO ALFA, S.A.",
+				"Se anuncia el extravío del certificado de depósito número 1234.\nOtro párrafo.")).To(gomega.Equal(models.TipoCPerdidaCertificados))
+			gomega.Expect(seccionc.Classify("OTROS ANUNCIOS Y AVISOS LEGALES", "ALFA, S.L.",
+				"Acuerdo de transformación en sociedad anónima.")).To(gomega.Equal(models.TipoCTransformacion))
+			gomega.Expect(seccionc.Classify("OTROS ANUNCIOS Y AVISOS LEGALES", "ALFA, S.L.",
+				"Anuncio de emisión de obligaciones.")).To(gomega.Equal(models.TipoCOtros))
+		})
+
+		ginkgo.It("should classify parsed announcements", func() {
+			fusion, err := seccionc.NewParser("testdata/BORME-C-2011-20488.xml").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(fusion.Tipo).To(gomega.Equal(models.TipoCFusion))
+
+			convocatoria, err := seccionc.NewParser("testdata/BORME-C-2015-6112.html").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(convocatoria.Tipo).To(gomega.Equal(models.TipoCConvocatoriaJunta))
+		})
+
+		ginkgo.It("should filter announcements by type", func() {
+			opts := parser.Options{FilterC: models.TipoCFilter(models.TipoCConvocatoriaJunta)}
+			_, err := parser.ParseWith("testdata/BORME-C-2011-20488.xml", models.SeccionC, opts)
+			gomega.Expect(err).To(gomega.MatchError(parser.ErrFiltered))
+
+			result, err := parser.ParseWith("testdata/BORME-C-2015-6112.html", models.SeccionC, opts)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(result.(*models.BormeC).Tipo).To(gomega.Equal(models.TipoCConvocatoriaJunta))
+		})
+	})
+
+	ginkgo.Describe("ParseConvocatoria", func() {
+		ginkgo.It("should extract the convocatoria of a junta", func() {
+			borme, err := seccionc.NewParser("testdata/BORME-C-2015-6112.html").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			c := borme.Convocatoria
+			gomega.Expect(c).ToNot(gomega.BeNil())
+			gomega.Expect(c.TipoJunta).To(gomega.Equal(models.JuntaOrdinaria))
+			gomega.Expect(c.PrimeraConvocatoria).To(gomega.Equal(time.Date(2015, time.June, 30, 12, 0, 0, 0, time.UTC)))
+			gomega.Expect(c.SegundaConvocatoria).To(gomega.Equal(time.Date(2015, time.July, 1, 12, 0, 0, 0, time.UTC)))
+			gomega.Expect(c.Lugar).To(gomega.Equal("Sevilla, calle Virgen de Luján, número 12"))
+			gomega.Expect(c.OrdenDelDia).To(gomega.HaveLen(4))
+			gomega.Expect(c.OrdenDelDia[1]).To(gomega.Equal("Aplicación del resultado."))
+			gomega.Expect(c.OrdenDelDia[3]).To(gomega.Equal("Ruegos y preguntas."))
+			gomega.Expect(c.Convocante).To(gomega.Equal("Consejo de Administración"))
+		})
+
+		ginkgo.It("should read calls given after their mention and numbered agendas", func() {
+			c := seccionc.ParseConvocatoria("El Administrador único convoca Junta General Extraordinaria y Ordinaria de socios, " +
+				"que se celebrará en la Notaría de D. Luis Gil, calle Mayor, 3, de Madrid, en primera convocatoria, " +
+				"el día 12 de marzo de 2019, a las 10.30 horas, y en segunda convocatoria el día 13 de marzo de 2019, " +
+				"a las 11 horas. Orden del día: 1º.- Cese y nombramiento de administradores. 2º.- Traslado del domicilio social.\n" +
+				"Madrid, 20 de febrero de 2019.- El Administrador único, Ana Ruiz Gil.")
+			gomega.Expect(c.TipoJunta).To(gomega.Equal(models.JuntaOrdinariaExtraordinaria))
+			gomega.Expect(c.PrimeraConvocatoria).To(gomega.Equal(time.Date(2019, time.March, 12, 10, 30, 0, 0, time.UTC)))
+			gomega.Expect(c.SegundaConvocatoria).To(gomega.Equal(time.Date(2019, time.March, 13, 11, 0, 0, 0, time.UTC)))
+			gomega.Expect(c.Lugar).To(gomega.Equal("la Notaría de D. Luis Gil, calle Mayor, 3, de Madrid"))
+			gomega.Expect(c.OrdenDelDia).To(gomega.Equal([]string{"Cese y nombramiento de administradores.", "Traslado del domicilio social."}))
+			gomega.Expect(c.Convocante).To(gomega.Equal("Administrador único"))
+		})
+
+		ginkgo.It("should not set a convocatoria on other announcements", func() {
+			borme, err := seccionc.NewParser("testdata/BORME-C-2011-20488.xml").Parse()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(borme.Convocatoria).To(gomega.BeNil())
+		})
+	})
+
 	ginkgo.Describe("ParseMultipleXML", func() {
+		ginkgo.It("should parse every documento", func() {
+			result, err := seccionc.ParseMultipleXML("testdata/BORME-C-2011-20488.xml")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(result).To(gomega.HaveLen(1))
+			gomega.Expect(result[0].Titulo).To(gomega.HavePrefix("DISTRIBUCIONES COMERCIALES LEVANTE"))
+		})
+
 		ginkgo.It("should return error for non-existent file", func() {
 			_, err := seccionc.ParseMultipleXML("testdata/nonexistent.xml")
 			gomega.Expect(err).To(gomega.HaveOccurred())